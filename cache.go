@@ -1,6 +1,7 @@
 package gorocksdb
 
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
 
 // Cache is a cache used to store data read from data in memory.
@@ -8,16 +9,82 @@ type Cache struct {
 	c *C.rocksdb_cache_t
 }
 
+// LRUCacheOptions are the options of an LRU cache created with
+// NewLRUCacheWithOptions.
+type LRUCacheOptions struct {
+	// Capacity is the size of the cache in bytes.
+	Capacity int
+
+	// NumShardBits is the log2 of the number of shards the cache is split
+	// into. Each shard gets capacity / 2^NumShardBits bytes. If negative
+	// the number of shards is chosen automatically based on the capacity;
+	// 0 makes the cache a single shard.
+	NumShardBits int
+
+	// StrictCapacityLimit makes inserts fail once the cache is full,
+	// instead of letting the usage exceed the capacity while entries are
+	// pinned. Reads of blocks which cannot be inserted fail as well.
+	StrictCapacityLimit bool
+
+	// HighPriPoolRatio is the fraction of the capacity reserved for high
+	// priority entries, like index and filter blocks cached with high
+	// priority. It must be between 0 and 1; 0 disables the high priority
+	// pool.
+	HighPriPoolRatio float64
+}
+
 // NewLRUCache creates a new LRU Cache object with the capacity given.
 func NewLRUCache(capacity int) *Cache {
 	return NewNativeCache(C.rocksdb_cache_create_lru(C.size_t(capacity)))
 }
 
+// NewLRUCacheWithOptions creates a new LRU Cache object with the options
+// given.
+func NewLRUCacheWithOptions(opts LRUCacheOptions) *Cache {
+	return NewNativeCache(C.gorocksdb_cache_create_lru(
+		C.size_t(opts.Capacity),
+		C.int(opts.NumShardBits),
+		boolToChar(opts.StrictCapacityLimit),
+		C.double(opts.HighPriPoolRatio),
+	))
+}
+
+// NewHyperClockCache creates a new HyperClockCache object with the capacity
+// given. HyperClockCache scales better than an LRU cache under concurrent
+// reads. The estimatedEntryCharge is the expected average size of an entry
+// in bytes, usually the block size; if 0 it is tuned automatically.
+func NewHyperClockCache(capacity, estimatedEntryCharge int) *Cache {
+	return NewNativeCache(C.rocksdb_cache_create_hyper_clock(C.size_t(capacity), C.size_t(estimatedEntryCharge)))
+}
+
 // NewNativeCache creates a Cache object.
 func NewNativeCache(c *C.rocksdb_cache_t) *Cache {
 	return &Cache{c}
 }
 
+// GetUsage returns the memory size of the entries residing in the cache.
+func (c *Cache) GetUsage() int {
+	return int(C.rocksdb_cache_get_usage(c.c))
+}
+
+// GetPinnedUsage returns the memory size of the entries which are in use
+// and therefore cannot be evicted.
+func (c *Cache) GetPinnedUsage() int {
+	return int(C.rocksdb_cache_get_pinned_usage(c.c))
+}
+
+// SetCapacity sets the maximum configured capacity of the cache. When the
+// new capacity is less than the old capacity and the existing usage is
+// greater than the new capacity, entries are evicted until the usage fits.
+func (c *Cache) SetCapacity(capacity int) {
+	C.rocksdb_cache_set_capacity(c.c, C.size_t(capacity))
+}
+
+// GetCapacity returns the maximum configured capacity of the cache.
+func (c *Cache) GetCapacity() int {
+	return int(C.rocksdb_cache_get_capacity(c.c))
+}
+
 // Destroy deallocates the Cache object.
 func (c *Cache) Destroy() {
 	C.rocksdb_cache_destroy(c.c)
@@ -0,0 +1,77 @@
+package gorocksdb
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestLRUCacheCapacity(t *testing.T) {
+	cache := NewLRUCacheWithOptions(LRUCacheOptions{
+		Capacity:            8 << 20,
+		NumShardBits:        2,
+		StrictCapacityLimit: true,
+		HighPriPoolRatio:    0.2,
+	})
+	defer cache.Destroy()
+	ensure.DeepEqual(t, cache.GetCapacity(), 8<<20)
+	ensure.DeepEqual(t, cache.GetUsage(), 0)
+	ensure.DeepEqual(t, cache.GetPinnedUsage(), 0)
+
+	cache.SetCapacity(16 << 20)
+	ensure.DeepEqual(t, cache.GetCapacity(), 16<<20)
+}
+
+func TestHyperClockCache(t *testing.T) {
+	cache := NewHyperClockCache(8<<20, 4<<10)
+	defer cache.Destroy()
+	ensure.DeepEqual(t, cache.GetCapacity(), 8<<20)
+
+	bbto := NewDefaultBlockBasedTableOptions()
+	defer bbto.Destroy()
+	bbto.SetBlockCache(cache)
+	db := newTestDB(t, "TestHyperClockCache", func(opts *Options) {
+		opts.SetBlockBasedTableFactory(bbto)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("key"), []byte("val")))
+	ensure.Nil(t, db.Flush(fo))
+	v, err := db.Get(ro, []byte("key"))
+	ensure.Nil(t, err)
+	defer v.Free()
+	ensure.DeepEqual(t, v.Data(), []byte("val"))
+	ensure.True(t, cache.GetUsage() > 0)
+}
+
+func TestRowCache(t *testing.T) {
+	cache := NewLRUCache(8 << 20)
+	defer cache.Destroy()
+	db := newTestDB(t, "TestRowCache", func(opts *Options) {
+		opts.SetRowCache(cache)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("key"), []byte("val")))
+	ensure.Nil(t, db.Flush(fo))
+	ensure.DeepEqual(t, cache.GetUsage(), 0)
+
+	// the row cache is filled by point lookups in table files
+	v, err := db.Get(ro, []byte("key"))
+	ensure.Nil(t, err)
+	defer v.Free()
+	ensure.DeepEqual(t, v.Data(), []byte("val"))
+	ensure.True(t, cache.GetUsage() > 0)
+}
@@ -3,16 +3,22 @@ package gorocksdb
 // #include <stdlib.h>
 // #include "rocksdb/c.h"
 import "C"
-import "unsafe"
+import (
+	"sync"
+	"unsafe"
+)
 
 // ColumnFamilyHandle represents a handle to a ColumnFamily.
 type ColumnFamilyHandle struct {
 	c *C.rocksdb_column_family_handle_t
+
+	// The DB which tracks the handle, if any.
+	db *DB
 }
 
 // NewNativeColumnFamilyHandle creates a ColumnFamilyHandle object.
 func NewNativeColumnFamilyHandle(c *C.rocksdb_column_family_handle_t) *ColumnFamilyHandle {
-	return &ColumnFamilyHandle{c}
+	return &ColumnFamilyHandle{c: c}
 }
 
 // UnsafeGetCFHandler returns the underlying c column family handle.
@@ -20,7 +26,99 @@ func (h *ColumnFamilyHandle) UnsafeGetCFHandler() unsafe.Pointer {
 	return unsafe.Pointer(h.c)
 }
 
+// Name returns the name of the column family.
+func (h *ColumnFamilyHandle) Name() string {
+	var cLen C.size_t
+	cName := C.rocksdb_column_family_handle_get_name(h.c, &cLen)
+	defer C.free(unsafe.Pointer(cName))
+	return C.GoStringN(cName, C.int(cLen))
+}
+
+// ID returns the ID of the column family, which is unique within the
+// database.
+func (h *ColumnFamilyHandle) ID() uint32 {
+	return uint32(C.rocksdb_column_family_handle_get_id(h.c))
+}
+
 // Destroy calls the destructor of the underlying column family handle.
+// It does nothing for the handles owned by a DB, i.e. those returned by
+// OpenDbColumnFamilies, CreateColumnFamily or DB.ColumnFamily, as they
+// may be shared. Those stay valid until DB.Close destroys them.
 func (h *ColumnFamilyHandle) Destroy() {
+	if h.db != nil || h.c == nil {
+		return
+	}
 	C.rocksdb_column_family_handle_destroy(h.c)
+	h.c = nil
+}
+
+// cfTracker keeps the column family handles a DB handed out, so that they
+// can be looked up by name and destroyed when the DB is closed.
+type cfTracker struct {
+	mu      sync.Mutex
+	byName  map[string]*ColumnFamilyHandle
+	handles map[*ColumnFamilyHandle]struct{}
+}
+
+func newCFTracker() *cfTracker {
+	return &cfTracker{
+		byName:  make(map[string]*ColumnFamilyHandle),
+		handles: make(map[*ColumnFamilyHandle]struct{}),
+	}
+}
+
+// track creates a handle for c which is destroyed when the DB is closed.
+func (t *cfTracker) track(db *DB, c *C.rocksdb_column_family_handle_t, name string) *ColumnFamilyHandle {
+	h := &ColumnFamilyHandle{c: c, db: db}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.byName[name] = h
+	t.handles[h] = struct{}{}
+	return h
+}
+
+// trackDefault returns the handle of the default column family, creating
+// it if it is not tracked yet.
+func (t *cfTracker) trackDefault(db *DB) *ColumnFamilyHandle {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if h := t.byName["default"]; h != nil {
+		return h
+	}
+	h := &ColumnFamilyHandle{c: C.rocksdb_get_default_column_family_handle(db.c), db: db}
+	t.byName["default"] = h
+	t.handles[h] = struct{}{}
+	return h
+}
+
+// lookup returns the handle of the column family name, or nil.
+func (t *cfTracker) lookup(name string) *ColumnFamilyHandle {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.byName[name]
+}
+
+// forget removes the handle from the name lookup after its column family
+// was dropped. It is still destroyed when the DB is closed.
+func (t *cfTracker) forget(h *ColumnFamilyHandle) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for name, other := range t.byName {
+		if other == h {
+			delete(t.byName, name)
+		}
+	}
+}
+
+// destroyAll destroys all tracked handles.
+func (t *cfTracker) destroyAll() {
+	t.mu.Lock()
+	handles := t.handles
+	t.handles = make(map[*ColumnFamilyHandle]struct{})
+	t.byName = make(map[string]*ColumnFamilyHandle)
+	t.mu.Unlock()
+	for h := range handles {
+		C.rocksdb_column_family_handle_destroy(h.c)
+		h.c = nil
+	}
 }
@@ -2,6 +2,7 @@ package gorocksdb
 
 import (
 	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/facebookgo/ensure"
@@ -52,6 +53,89 @@ func TestColumnFamilyCreateDrop(t *testing.T) {
 	ensure.SameElements(t, actualNames, []string{"default"})
 }
 
+func TestColumnFamiliesCreateDrop(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestColumnFamiliesCreateDrop")
+	ensure.Nil(t, err)
+
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissing(true)
+	db, err := OpenDb(opts, dir)
+	ensure.Nil(t, err)
+
+	givenNames := []string{"tenant1", "tenant2", "tenant3"}
+	cfs, err := db.CreateColumnFamilies(opts, givenNames)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(cfs), 3)
+	for i, cf := range cfs {
+		ensure.DeepEqual(t, cf.Name(), givenNames[i])
+		ensure.True(t, db.ColumnFamily(givenNames[i]) == cf)
+	}
+
+	// a name which exists already fails the whole batch
+	_, err = db.CreateColumnFamiliesWithOptions([]string{"tenant4", "tenant1"}, []*Options{opts, opts})
+	ensure.NotNil(t, err)
+	ensure.True(t, db.ColumnFamily("tenant4") == nil)
+
+	_, err = db.CreateColumnFamilies(opts, []string{"tenant5", "tenant2"})
+	ensure.NotNil(t, err)
+	ensure.True(t, db.ColumnFamily("tenant5") == nil)
+
+	_, err = db.CreateColumnFamiliesWithOptions([]string{"tenant6"}, nil)
+	ensure.NotNil(t, err)
+
+	// so does a name given twice
+	_, err = db.CreateColumnFamilies(opts, []string{"tenant7", "tenant7"})
+	ensure.NotNil(t, err)
+	ensure.True(t, db.ColumnFamily("tenant7") == nil)
+
+	// the default column family can't be dropped, so nothing is dropped
+	err = db.DropColumnFamilies([]*ColumnFamilyHandle{cfs[0], db.ColumnFamily("default")})
+	ensure.NotNil(t, err)
+	db.Close()
+
+	actualNames, err := ListColumnFamilies(opts, dir)
+	ensure.Nil(t, err)
+	ensure.SameElements(t, actualNames, []string{"default", "tenant1", "tenant2", "tenant3"})
+
+	db, cfs, err = OpenDbColumnFamilies(opts, dir, actualNames, []*Options{opts, opts, opts, opts})
+	ensure.Nil(t, err)
+	ensure.Nil(t, db.DropColumnFamilies(cfs[1:]))
+	ensure.True(t, db.ColumnFamily("tenant1") == nil)
+	db.Close()
+
+	actualNames, err = ListColumnFamilies(opts, dir)
+	ensure.Nil(t, err)
+	ensure.SameElements(t, actualNames, []string{"default"})
+}
+
+func TestColumnFamiliesDropDropped(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestColumnFamiliesDropDropped")
+	ensure.Nil(t, err)
+
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissing(true)
+	db, err := OpenDb(opts, dir)
+	ensure.Nil(t, err)
+
+	cfs, err := db.CreateColumnFamilies(opts, []string{"tenant1", "tenant2", "tenant3"})
+	ensure.Nil(t, err)
+	ensure.Nil(t, db.DropColumnFamily(cfs[1]))
+
+	// tenant2 is dropped already, so neither tenant1 nor tenant3 is dropped
+	ensure.NotNil(t, db.DropColumnFamilies(cfs))
+	ensure.True(t, db.ColumnFamily("tenant1") == cfs[0])
+	ensure.True(t, db.ColumnFamily("tenant3") == cfs[2])
+
+	// nor if a handle is given twice
+	ensure.NotNil(t, db.DropColumnFamilies([]*ColumnFamilyHandle{cfs[0], cfs[0]}))
+	ensure.True(t, db.ColumnFamily("tenant1") == cfs[0])
+	db.Close()
+
+	actualNames, err := ListColumnFamilies(opts, dir)
+	ensure.Nil(t, err)
+	ensure.SameElements(t, actualNames, []string{"default", "tenant1", "tenant3"})
+}
+
 func TestColumnFamilyBatchPutGet(t *testing.T) {
 	dir, err := ioutil.TempDir("", "gorocksdb-TestColumnFamilyPutGet")
 	ensure.Nil(t, err)
@@ -152,3 +236,126 @@ func TestColumnFamilyPutGetDelete(t *testing.T) {
 	ensure.Nil(t, err)
 	ensure.DeepEqual(t, actualVal.Size(), 0)
 }
+
+func TestColumnFamilyMetadata(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestColumnFamilyMetadata")
+	ensure.Nil(t, err)
+
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.SetCreateIfMissing(true)
+	db, cfh, err := OpenDbColumnFamilies(opts, dir, []string{"default", "guide"}, []*Options{opts, opts})
+	ensure.Nil(t, err)
+	defer db.Close()
+	defer cfh[0].Destroy()
+	defer cfh[1].Destroy()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val")))
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("val")))
+	ensure.Nil(t, db.Delete(wo, []byte("key0")))
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	ensure.Nil(t, db.Flush(fo))
+	ensure.Nil(t, db.PutCF(wo, cfh[1], []byte("key"), []byte("val")))
+	db.CompactRangeCF(cfh[1], Range{nil, nil})
+
+	liveFiles := db.GetLiveFilesMetaData()
+	ensure.DeepEqual(t, len(liveFiles), 2)
+	var live LiveFileMetadata
+	for _, f := range liveFiles {
+		if f.ColumnFamilyName == "default" {
+			live = f
+		} else {
+			ensure.DeepEqual(t, f.ColumnFamilyName, "guide")
+		}
+	}
+	ensure.DeepEqual(t, live.Directory, dir)
+	ensure.DeepEqual(t, live.Level, 0)
+	ensure.DeepEqual(t, live.NumEntries, uint64(3))
+	ensure.DeepEqual(t, live.NumDeletions, uint64(1))
+	ensure.DeepEqual(t, live.SmallestSeqno, uint64(1))
+	ensure.DeepEqual(t, live.LargestSeqno, uint64(3))
+	ensure.DeepEqual(t, live.SmallestKey, []byte("key0"))
+	ensure.DeepEqual(t, live.LargestKey, []byte("key2"))
+
+	meta := db.GetColumnFamilyMetadata(nil)
+	ensure.DeepEqual(t, meta.Name, "default")
+	ensure.DeepEqual(t, meta.FileCount, 1)
+	ensure.DeepEqual(t, meta.Size, uint64(live.Size))
+	ensure.DeepEqual(t, meta.Levels[0].Level, 0)
+	ensure.DeepEqual(t, meta.Levels[0].Size, uint64(live.Size))
+	ensure.DeepEqual(t, len(meta.Levels[0].Files), 1)
+	file := meta.Levels[0].Files[0]
+	ensure.True(t, strings.HasSuffix(live.Name, file.Name))
+	ensure.DeepEqual(t, file.Directory, dir)
+	ensure.DeepEqual(t, file.Size, uint64(live.Size))
+	ensure.DeepEqual(t, file.SmallestKey, []byte("key0"))
+	ensure.DeepEqual(t, file.LargestKey, []byte("key2"))
+
+	meta = db.GetColumnFamilyMetadata(cfh[1])
+	ensure.DeepEqual(t, meta.Name, "guide")
+	ensure.DeepEqual(t, meta.FileCount, 1)
+	ensure.DeepEqual(t, len(meta.Levels), opts.GetNumLevels())
+}
+
+func TestColumnFamilyLookup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestColumnFamilyLookup")
+	ensure.Nil(t, err)
+
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.SetCreateIfMissing(true)
+	db, cfh, err := OpenDbColumnFamilies(opts, dir, []string{"default", "guide"}, []*Options{opts, opts})
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, cfh[0].Name(), "default")
+	ensure.DeepEqual(t, cfh[0].ID(), uint32(0))
+	ensure.DeepEqual(t, cfh[1].Name(), "guide")
+	ensure.True(t, cfh[1].ID() != 0)
+	ensure.True(t, db.ColumnFamily("default") == cfh[0])
+	ensure.True(t, db.ColumnFamily("guide") == cfh[1])
+	ensure.True(t, db.ColumnFamily("unknown") == nil)
+
+	cf, err := db.CreateColumnFamily(opts, "other")
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, cf.Name(), "other")
+	ensure.True(t, db.ColumnFamily("other") == cf)
+
+	// dropped column families can't be looked up anymore
+	ensure.Nil(t, db.DropColumnFamily(cfh[1]))
+	ensure.True(t, db.ColumnFamily("guide") == nil)
+
+	// the handles are owned by the db, so destroying them does nothing
+	cfh[0].Destroy()
+	cfh[0].Destroy()
+	ensure.True(t, cfh[0].UnsafeGetCFHandler() != nil)
+	ensure.True(t, db.ColumnFamily("default") == cfh[0])
+	ensure.DeepEqual(t, cfh[0].Name(), "default")
+
+	// Close destroys the handles
+	db.Close()
+	ensure.True(t, cfh[0].UnsafeGetCFHandler() == nil)
+	ensure.True(t, cf.UnsafeGetCFHandler() == nil)
+	ensure.True(t, cfh[1].UnsafeGetCFHandler() == nil)
+	cf.Destroy()
+}
+
+func TestColumnFamilyDefaultLookup(t *testing.T) {
+	db := newTestDB(t, "TestColumnFamilyDefaultLookup", nil)
+	defer db.Close()
+
+	cf := db.ColumnFamily("default")
+	ensure.True(t, cf != nil)
+	ensure.True(t, db.ColumnFamily("default") == cf)
+	ensure.DeepEqual(t, cf.Name(), "default")
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	ensure.Nil(t, db.PutCF(wo, cf, []byte("key"), []byte("val")))
+	v, err := db.GetBytes(ro, []byte("key"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v, []byte("val"))
+}
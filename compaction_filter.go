@@ -1,6 +1,7 @@
 package gorocksdb
 
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
 
 // A CompactionFilter can be used to filter keys during compaction time.
@@ -40,11 +41,14 @@ func (c nativeCompactionFilter) Filter(level int, key, val []byte) (remove bool,
 func (c nativeCompactionFilter) Name() string { return "" }
 
 // Hold references to compaction filters.
-var compactionFilters []CompactionFilter
+var compactionFilters = newRegistry()
 
 func registerCompactionFilter(filter CompactionFilter) int {
-	compactionFilters = append(compactionFilters, filter)
-	return len(compactionFilters) - 1
+	return compactionFilters.register(filter)
+}
+
+func getCompactionFilter(idx int) CompactionFilter {
+	return compactionFilters.lookup(idx).(CompactionFilter)
 }
 
 //export gorocksdb_compactionfilter_filter
@@ -52,7 +56,7 @@ func gorocksdb_compactionfilter_filter(idx int, cLevel C.int, cKey *C.char, cKey
 	key := charToByte(cKey, cKeyLen)
 	val := charToByte(cVal, cValLen)
 
-	remove, newVal := compactionFilters[idx].Filter(int(cLevel), key, val)
+	remove, newVal := getCompactionFilter(idx).Filter(int(cLevel), key, val)
 	if remove {
 		return C.int(1)
 	} else if newVal != nil {
@@ -65,5 +69,93 @@ func gorocksdb_compactionfilter_filter(idx int, cLevel C.int, cKey *C.char, cKey
 
 //export gorocksdb_compactionfilter_name
 func gorocksdb_compactionfilter_name(idx int) *C.char {
-	return stringToChar(compactionFilters[idx].Name())
+	return stringToChar(getCompactionFilter(idx).Name())
+}
+
+//export gorocksdb_compactionfilter_destruct
+func gorocksdb_compactionfilter_destruct(idx int) {
+	compactionFilters.unregister(idx)
+}
+
+// CompactionFilterContext describes the compaction a CompactionFilter is
+// created for.
+type CompactionFilterContext struct {
+	// IsFullCompaction is true if the compaction includes all table files.
+	IsFullCompaction bool
+
+	// IsManualCompaction is true if the compaction was requested by the
+	// application, e.g. with CompactRange.
+	IsManualCompaction bool
+
+	// ColumnFamilyID is the ID of the column family which is compacted.
+	ColumnFamilyID uint32
+}
+
+// A CompactionFilterFactory creates a new CompactionFilter for each
+// compaction. Each filter is only used from a single thread, so it doesn't
+// need to be thread-safe, but CreateCompactionFilter may be called from
+// different threads concurrently.
+type CompactionFilterFactory interface {
+	// CreateCompactionFilter returns the filter for the compaction described
+	// by ctx, or nil to not filter the compaction. The compaction takes
+	// ownership of the filter and destroys it when done, so a native filter
+	// must be newly created on each call and must not be used afterwards.
+	CreateCompactionFilter(ctx CompactionFilterContext) CompactionFilter
+
+	// The name of the compaction filter factory, for logging
+	Name() string
+}
+
+// NewNativeCompactionFilterFactory creates a CompactionFilterFactory object.
+func NewNativeCompactionFilterFactory(c *C.rocksdb_compactionfilterfactory_t) CompactionFilterFactory {
+	return nativeCompactionFilterFactory{c}
+}
+
+type nativeCompactionFilterFactory struct {
+	c *C.rocksdb_compactionfilterfactory_t
+}
+
+func (f nativeCompactionFilterFactory) CreateCompactionFilter(ctx CompactionFilterContext) CompactionFilter {
+	return nil
+}
+func (f nativeCompactionFilterFactory) Name() string { return "" }
+
+// Hold references to compaction filter factories.
+var compactionFilterFactories = newRegistry()
+
+func registerCompactionFilterFactory(factory CompactionFilterFactory) int {
+	return compactionFilterFactories.register(factory)
+}
+
+func getCompactionFilterFactory(idx int) CompactionFilterFactory {
+	return compactionFilterFactories.lookup(idx).(CompactionFilterFactory)
+}
+
+//export gorocksdb_compactionfilterfactory_create_filter
+func gorocksdb_compactionfilterfactory_create_filter(idx int, cContext *C.rocksdb_compactionfiltercontext_t) *C.rocksdb_compactionfilter_t {
+	ctx := CompactionFilterContext{
+		IsFullCompaction:   charToBool(C.rocksdb_compactionfiltercontext_is_full_compaction(cContext)),
+		IsManualCompaction: charToBool(C.rocksdb_compactionfiltercontext_is_manual_compaction(cContext)),
+		ColumnFamilyID:     uint32(C.gorocksdb_compactionfiltercontext_column_family_id(cContext)),
+	}
+	filter := getCompactionFilterFactory(idx).CreateCompactionFilter(ctx)
+	if filter == nil {
+		return nil
+	}
+	// The filter is owned by the compaction, which destroys it when done.
+	// Returning the same native filter twice would free it twice.
+	if nc, ok := filter.(nativeCompactionFilter); ok {
+		return nc.c
+	}
+	return C.gorocksdb_compactionfilter_create(C.uintptr_t(registerCompactionFilter(filter)))
+}
+
+//export gorocksdb_compactionfilterfactory_name
+func gorocksdb_compactionfilterfactory_name(idx int) *C.char {
+	return stringToChar(getCompactionFilterFactory(idx).Name())
+}
+
+//export gorocksdb_compactionfilterfactory_destruct
+func gorocksdb_compactionfilterfactory_destruct(idx int) {
+	compactionFilterFactories.unregister(idx)
 }
@@ -2,6 +2,7 @@ package gorocksdb
 
 import (
 	"bytes"
+	"sync"
 	"testing"
 
 	"github.com/facebookgo/ensure"
@@ -51,6 +52,81 @@ func TestCompactionFilter(t *testing.T) {
 	ensure.True(t, v2.Data() == nil)
 }
 
+func TestCompactionFilterFactory(t *testing.T) {
+	var (
+		expiredKey = []byte("expired")
+		liveKey    = []byte("live")
+		mu         sync.Mutex
+		contexts   []CompactionFilterContext
+		filters    []CompactionFilter
+	)
+	db := newTestDB(t, "TestCompactionFilterFactory", func(opts *Options) {
+		opts.SetCompactionFilterFactory(&mockCompactionFilterFactory{
+			create: func(ctx CompactionFilterContext) CompactionFilter {
+				if !ctx.IsManualCompaction {
+					return nil
+				}
+				filter := &mockCompactionFilter{
+					filter: func(level int, key, val []byte) (bool, []byte) {
+						return bytes.Equal(key, expiredKey), nil
+					},
+				}
+				mu.Lock()
+				contexts = append(contexts, ctx)
+				filters = append(filters, filter)
+				mu.Unlock()
+				return filter
+			},
+		})
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.Put(wo, expiredKey, []byte("val")))
+	ensure.Nil(t, db.Put(wo, liveKey, []byte("val")))
+	db.CompactRange(Range{nil, nil})
+
+	mu.Lock()
+	ensure.True(t, len(contexts) > 0)
+	ensure.True(t, contexts[0].IsFullCompaction)
+	ensure.DeepEqual(t, contexts[0].ColumnFamilyID, db.ColumnFamily("default").ID())
+	created := len(filters)
+	mu.Unlock()
+
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	v1, err := db.Get(ro, expiredKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v1.Data() == nil)
+	v1.Free()
+	v2, err := db.Get(ro, liveKey)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v2.Data(), []byte("val"))
+	v2.Free()
+
+	// every compaction gets a new filter
+	ensure.Nil(t, db.Put(wo, expiredKey, []byte("val")))
+	db.CompactRange(Range{nil, nil})
+	mu.Lock()
+	ensure.True(t, len(filters) > created)
+	ensure.True(t, filters[created] != filters[0])
+	mu.Unlock()
+	v3, err := db.Get(ro, expiredKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v3.Data() == nil)
+	v3.Free()
+}
+
+type mockCompactionFilterFactory struct {
+	create func(ctx CompactionFilterContext) CompactionFilter
+}
+
+func (m *mockCompactionFilterFactory) Name() string { return "gorocksdb.test" }
+func (m *mockCompactionFilterFactory) CreateCompactionFilter(ctx CompactionFilterContext) CompactionFilter {
+	return m.create(ctx)
+}
+
 type mockCompactionFilter struct {
 	filter func(level int, key, val []byte) (remove bool, newVal []byte)
 }
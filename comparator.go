@@ -28,22 +28,30 @@ type nativeComparator struct {
 func (c nativeComparator) Compare(a, b []byte) int { return 0 }
 func (c nativeComparator) Name() string            { return "" }
 
-// Hold references to comperators.
-var comperators []Comparator
+// Hold references to comparators.
+var comparators = newRegistry()
 
-func registerComperator(cmp Comparator) int {
-	comperators = append(comperators, cmp)
-	return len(comperators) - 1
+func registerComparator(cmp Comparator) int {
+	return comparators.register(cmp)
+}
+
+func getComparator(idx int) Comparator {
+	return comparators.lookup(idx).(Comparator)
 }
 
 //export gorocksdb_comparator_compare
 func gorocksdb_comparator_compare(idx int, cKeyA *C.char, cKeyALen C.size_t, cKeyB *C.char, cKeyBLen C.size_t) C.int {
 	keyA := charToByte(cKeyA, cKeyALen)
 	keyB := charToByte(cKeyB, cKeyBLen)
-	return C.int(comperators[idx].Compare(keyA, keyB))
+	return C.int(getComparator(idx).Compare(keyA, keyB))
 }
 
 //export gorocksdb_comparator_name
 func gorocksdb_comparator_name(idx int) *C.char {
-	return stringToChar(comperators[idx].Name())
+	return stringToChar(getComparator(idx).Name())
+}
+
+//export gorocksdb_comparator_destruct
+func gorocksdb_comparator_destruct(idx int) {
+	comparators.unregister(idx)
 }
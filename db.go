@@ -2,9 +2,11 @@ package gorocksdb
 
 // #include <stdlib.h>
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
 import (
 	"errors"
+	"time"
 	"unsafe"
 )
 
@@ -20,6 +22,13 @@ type DB struct {
 	c    *C.rocksdb_t
 	name string
 	opts *Options
+
+	// Tracks iterators and snapshots which have to be released before the
+	// database can be closed.
+	refs *refTracker
+
+	// Tracks the column family handles, which are destroyed on Close.
+	cfs *cfTracker
 }
 
 // OpenDb opens a database with the specified options.
@@ -38,6 +47,8 @@ func OpenDb(opts *Options, name string) (*DB, error) {
 		name: name,
 		c:    db,
 		opts: opts,
+		refs: newRefTracker(),
+		cfs:  newCFTracker(),
 	}, nil
 }
 
@@ -57,6 +68,8 @@ func OpenDbForReadOnly(opts *Options, name string, errorIfLogFileExist bool) (*D
 		name: name,
 		c:    db,
 		opts: opts,
+		refs: newRefTracker(),
+		cfs:  newCFTracker(),
 	}, nil
 }
 
@@ -107,16 +120,18 @@ func OpenDbColumnFamilies(
 		return nil, nil, errors.New(C.GoString(cErr))
 	}
 
-	cfHandles := make([]*ColumnFamilyHandle, numColumnFamilies)
-	for i, c := range cHandles {
-		cfHandles[i] = NewNativeColumnFamilyHandle(c)
-	}
-
-	return &DB{
+	d := &DB{
 		name: name,
 		c:    db,
 		opts: opts,
-	}, cfHandles, nil
+		refs: newRefTracker(),
+		cfs:  newCFTracker(),
+	}
+	cfHandles := make([]*ColumnFamilyHandle, numColumnFamilies)
+	for i, c := range cHandles {
+		cfHandles[i] = d.cfs.track(d, c, cfNames[i])
+	}
+	return d, cfHandles, nil
 }
 
 // OpenDbForReadOnlyColumnFamilies opens a database with the specified column
@@ -169,16 +184,18 @@ func OpenDbForReadOnlyColumnFamilies(
 		return nil, nil, errors.New(C.GoString(cErr))
 	}
 
-	cfHandles := make([]*ColumnFamilyHandle, numColumnFamilies)
-	for i, c := range cHandles {
-		cfHandles[i] = NewNativeColumnFamilyHandle(c)
-	}
-
-	return &DB{
+	d := &DB{
 		name: name,
 		c:    db,
 		opts: opts,
-	}, cfHandles, nil
+		refs: newRefTracker(),
+		cfs:  newCFTracker(),
+	}
+	cfHandles := make([]*ColumnFamilyHandle, numColumnFamilies)
+	for i, c := range cHandles {
+		cfHandles[i] = d.cfs.track(d, c, cfNames[i])
+	}
+	return d, cfHandles, nil
 }
 
 // ListColumnFamilies lists the names of the column families in the DB.
@@ -273,7 +290,7 @@ func (db *DB) Put(opts *WriteOptions, key, value []byte) error {
 	C.rocksdb_put(db.c, opts.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -288,7 +305,7 @@ func (db *DB) PutCF(opts *WriteOptions, cf *ColumnFamilyHandle, key, value []byt
 	C.rocksdb_put_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -302,7 +319,7 @@ func (db *DB) Delete(opts *WriteOptions, key []byte) error {
 	C.rocksdb_delete(db.c, opts.c, cKey, C.size_t(len(key)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -316,7 +333,7 @@ func (db *DB) DeleteCF(opts *WriteOptions, cf *ColumnFamilyHandle, key []byte) e
 	C.rocksdb_delete_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -331,7 +348,7 @@ func (db *DB) Merge(opts *WriteOptions, key []byte, value []byte) error {
 	C.rocksdb_merge(db.c, opts.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -347,7 +364,7 @@ func (db *DB) MergeCF(opts *WriteOptions, cf *ColumnFamilyHandle, key []byte, va
 	C.rocksdb_merge_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -358,7 +375,7 @@ func (db *DB) Write(opts *WriteOptions, batch *WriteBatch) error {
 	C.rocksdb_write(db.c, opts.c, batch.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -366,21 +383,46 @@ func (db *DB) Write(opts *WriteOptions, batch *WriteBatch) error {
 // NewIterator returns an Iterator over the the database that uses the
 // ReadOptions given.
 func (db *DB) NewIterator(opts *ReadOptions) *Iterator {
+	// The reference is acquired first, so that Close can't close the
+	// database while the iterator is created.
+	release := db.refs.acquire("iterator", "")
 	cIter := C.rocksdb_create_iterator(db.c, opts.c)
-	return NewNativeIterator(unsafe.Pointer(cIter))
+	if cIter == nil {
+		release()
+		return nil
+	}
+	iter := NewNativeIterator(unsafe.Pointer(cIter))
+	iter.release = release
+	return iter
 }
 
 // NewIteratorCF returns an Iterator over the the database and column family
 // that uses the ReadOptions given.
 func (db *DB) NewIteratorCF(opts *ReadOptions, cf *ColumnFamilyHandle) *Iterator {
+	// The reference is acquired first, so that Close can't close the
+	// database while the iterator is created.
+	release := db.refs.acquire("iterator", "")
 	cIter := C.rocksdb_create_iterator_cf(db.c, opts.c, cf.c)
-	return NewNativeIterator(unsafe.Pointer(cIter))
+	if cIter == nil {
+		release()
+		return nil
+	}
+	iter := NewNativeIterator(unsafe.Pointer(cIter))
+	iter.release = release
+	return iter
 }
 
 // NewSnapshot creates a new snapshot of the database.
 func (db *DB) NewSnapshot() *Snapshot {
+	release := db.refs.acquire("snapshot", "")
 	cSnap := C.rocksdb_create_snapshot(db.c)
-	return NewNativeSnapshot(cSnap, db.c)
+	if cSnap == nil {
+		release()
+		return nil
+	}
+	snap := NewNativeSnapshot(cSnap, db.c)
+	snap.release = release
+	return snap
 }
 
 // GetProperty returns the value of a database property.
@@ -401,7 +443,80 @@ func (db *DB) GetPropertyCF(propName string, cf *ColumnFamilyHandle) string {
 	return C.GoString(cValue)
 }
 
-// CreateColumnFamily create a new column family.
+// GetIntProperty returns the value of an integer database property such as
+// PropertyEstimateNumKeys. The returned bool is false if the property is
+// unknown or not an integer property.
+func (db *DB) GetIntProperty(propName string) (uint64, bool) {
+	cProp := C.CString(propName)
+	defer C.free(unsafe.Pointer(cProp))
+	var cValue C.uint64_t
+	ok := C.rocksdb_property_int(db.c, cProp, &cValue) == 0
+	return uint64(cValue), ok
+}
+
+// GetIntPropertyCF returns the value of an integer property of the column
+// family.
+func (db *DB) GetIntPropertyCF(propName string, cf *ColumnFamilyHandle) (uint64, bool) {
+	cProp := C.CString(propName)
+	defer C.free(unsafe.Pointer(cProp))
+	var cValue C.uint64_t
+	ok := C.rocksdb_property_int_cf(db.c, cf.c, cProp, &cValue) == 0
+	return uint64(cValue), ok
+}
+
+// GetAggregatedIntProperty returns the sum of an integer property over all
+// column families.
+func (db *DB) GetAggregatedIntProperty(propName string) (uint64, bool) {
+	cProp := C.CString(propName)
+	defer C.free(unsafe.Pointer(cProp))
+	var cValue C.uint64_t
+	ok := C.gorocksdb_property_aggregated_int(db.c, cProp, &cValue) != 0
+	return uint64(cValue), ok
+}
+
+// GetMapProperty returns the value of a map database property such as
+// PropertyCFStats as key-value pairs. The returned bool is false if the
+// property is unknown or not a map property.
+func (db *DB) GetMapProperty(propName string) (map[string]string, bool) {
+	return db.getMapProperty(propName, nil)
+}
+
+// GetMapPropertyCF returns the value of a map property of the column family.
+func (db *DB) GetMapPropertyCF(propName string, cf *ColumnFamilyHandle) (map[string]string, bool) {
+	return db.getMapProperty(propName, cf.c)
+}
+
+func (db *DB) getMapProperty(propName string, cf *C.rocksdb_column_family_handle_t) (map[string]string, bool) {
+	cProp := C.CString(propName)
+	defer C.free(unsafe.Pointer(cProp))
+	var (
+		cNum    C.size_t
+		cKeys   **C.char
+		cValues **C.char
+	)
+	if C.gorocksdb_property_map_cf(db.c, cf, cProp, &cNum, &cKeys, &cValues) == 0 {
+		return nil, false
+	}
+	defer C.free(unsafe.Pointer(cKeys))
+	defer C.free(unsafe.Pointer(cValues))
+
+	num := int(cNum)
+	props := make(map[string]string, num)
+	if num == 0 {
+		return props, true
+	}
+	keys := (*[1 << 30]*C.char)(unsafe.Pointer(cKeys))[:num:num]
+	values := (*[1 << 30]*C.char)(unsafe.Pointer(cValues))[:num:num]
+	for i := 0; i < num; i++ {
+		props[C.GoString(keys[i])] = C.GoString(values[i])
+		C.free(unsafe.Pointer(keys[i]))
+		C.free(unsafe.Pointer(values[i]))
+	}
+	return props, true
+}
+
+// CreateColumnFamily create a new column family. The handle is owned by the
+// DB and destroyed by Close.
 func (db *DB) CreateColumnFamily(opts *Options, name string) (*ColumnFamilyHandle, error) {
 	var (
 		cErr  *C.char
@@ -413,10 +528,107 @@ func (db *DB) CreateColumnFamily(opts *Options, name string) (*ColumnFamilyHandl
 		defer C.free(unsafe.Pointer(cErr))
 		return nil, errors.New(C.GoString(cErr))
 	}
-	return NewNativeColumnFamilyHandle(cHandle), nil
+	return db.cfs.track(db, cHandle, name), nil
 }
 
-// DropColumnFamily drops a column family.
+// CreateColumnFamilies creates a column family for each name with the same
+// options. The handles are owned by the DB and destroyed by Close.
+//
+// The batch is all-or-nothing: if a name occurs twice or names an existing
+// column family, an error is returned before any is created. If RocksDB
+// fails to create one of them, the ones created before are dropped again
+// and the error is returned. Only if that rollback fails too, which is
+// added to the error, or the process crashes in between, some of them
+// remain.
+func (db *DB) CreateColumnFamilies(opts *Options, names []string) ([]*ColumnFamilyHandle, error) {
+	cfOpts := make([]*Options, len(names))
+	for i := range cfOpts {
+		cfOpts[i] = opts
+	}
+	return db.CreateColumnFamiliesWithOptions(names, cfOpts)
+}
+
+// CreateColumnFamiliesWithOptions creates a column family for each name
+// with the options at the same index. It behaves like CreateColumnFamilies.
+func (db *DB) CreateColumnFamiliesWithOptions(names []string, opts []*Options) ([]*ColumnFamilyHandle, error) {
+	numColumnFamilies := len(names)
+	if numColumnFamilies != len(opts) {
+		return nil, errors.New("must provide the same number of column family names and options")
+	}
+	if numColumnFamilies == 0 {
+		return nil, nil
+	}
+
+	cNames := make([]*C.char, numColumnFamilies)
+	for i, s := range names {
+		cNames[i] = C.CString(s)
+	}
+	defer func() {
+		for _, s := range cNames {
+			C.free(unsafe.Pointer(s))
+		}
+	}()
+
+	cOpts := make([]*C.rocksdb_options_t, numColumnFamilies)
+	for i, o := range opts {
+		cOpts[i] = o.c
+	}
+
+	var cErr *C.char
+	cHandles := C.gorocksdb_create_column_families(
+		db.c,
+		C.int(numColumnFamilies),
+		&cNames[0],
+		&cOpts[0],
+		&cErr,
+	)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, errors.New(C.GoString(cErr))
+	}
+	defer C.free(unsafe.Pointer(cHandles))
+
+	created := (*[1 << 30]*C.rocksdb_column_family_handle_t)(unsafe.Pointer(cHandles))[:numColumnFamilies:numColumnFamilies]
+	cfHandles := make([]*ColumnFamilyHandle, numColumnFamilies)
+	for i, c := range created {
+		cfHandles[i] = db.cfs.track(db, c, names[i])
+	}
+	return cfHandles, nil
+}
+
+// DropColumnFamilies drops the column families of the handles. If one of
+// them is the default column family, was dropped already or occurs twice,
+// an error is returned before any is dropped. If RocksDB fails to write
+// the drop of one of them, the ones before it stay dropped, as RocksDB
+// can't undo a drop. The handles stay valid until the DB is closed, but
+// ColumnFamily no longer returns them.
+func (db *DB) DropColumnFamilies(handles []*ColumnFamilyHandle) error {
+	if len(handles) == 0 {
+		return nil
+	}
+	cHandles := make([]*C.rocksdb_column_family_handle_t, len(handles))
+	for i, h := range handles {
+		cHandles[i] = h.c
+	}
+	var (
+		cErr     *C.char
+		cDropped = make([]C.uchar, len(handles))
+	)
+	C.gorocksdb_drop_column_families(db.c, &cHandles[0], C.int(len(cHandles)), &cDropped[0], &cErr)
+	for i, h := range handles {
+		if charToBool(cDropped[i]) {
+			db.cfs.forget(h)
+		}
+	}
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return errors.New(C.GoString(cErr))
+	}
+	return nil
+}
+
+// DropColumnFamily drops a column family. The handle stays valid until the
+// DB is closed, but ColumnFamily no longer returns it.
 func (db *DB) DropColumnFamily(c *ColumnFamilyHandle) error {
 	var cErr *C.char
 	C.rocksdb_drop_column_family(db.c, c.c, &cErr)
@@ -424,20 +636,62 @@ func (db *DB) DropColumnFamily(c *ColumnFamilyHandle) error {
 		defer C.free(unsafe.Pointer(cErr))
 		return errors.New(C.GoString(cErr))
 	}
+	db.cfs.forget(c)
 	return nil
 }
 
+// ColumnFamily returns the handle of the column family name which was
+// opened with OpenDbColumnFamilies or created with CreateColumnFamily, or
+// nil if there is none. The handle of the default column family is always
+// available. The handles are owned by the DB, so they may be shared by
+// several callers: ColumnFamilyHandle.Destroy does nothing for them and
+// they stay valid until Close destroys them.
+func (db *DB) ColumnFamily(name string) *ColumnFamilyHandle {
+	if name == "default" {
+		return db.cfs.trackDefault(db)
+	}
+	return db.cfs.lookup(name)
+}
+
+// SetOptions dynamically changes the mutable options of the default column
+// family, e.g. {"write_buffer_size": "131072", "disable_auto_compactions": "true"}.
+// An error is returned if an option is unknown or can't be changed on a
+// running database.
+func (db *DB) SetOptions(opts map[string]string) error {
+	return setOptions(opts, func(num C.int, keys, values **C.char, cErr **C.char) {
+		C.rocksdb_set_options(db.c, num, keys, values, cErr)
+	})
+}
+
+// SetOptionsCF dynamically changes the mutable options of the column family.
+// See SetOptions.
+func (db *DB) SetOptionsCF(cf *ColumnFamilyHandle, opts map[string]string) error {
+	return setOptions(opts, func(num C.int, keys, values **C.char, cErr **C.char) {
+		C.rocksdb_set_options_cf(db.c, cf.c, num, keys, values, cErr)
+	})
+}
+
+// SetDBOptions dynamically changes the mutable database wide options,
+// e.g. {"max_background_jobs": "4"}. An error is returned if an option is
+// unknown or can't be changed on a running database.
+func (db *DB) SetDBOptions(opts map[string]string) error {
+	return setOptions(opts, func(num C.int, keys, values **C.char, cErr **C.char) {
+		C.gorocksdb_set_db_options(db.c, num, keys, values, cErr)
+	})
+}
+
 // GetApproximateSizes returns the approximate number of bytes of file system
 // space used by one or more key ranges.
 //
 // The keys counted will begin at Range.Start and end on the key before
-// Range.Limit.
+// Range.Limit. The sizes are 0 if RocksDB fails to compute them.
 func (db *DB) GetApproximateSizes(ranges []Range) []uint64 {
 	sizes := make([]uint64, len(ranges))
 	if len(ranges) == 0 {
 		return sizes
 	}
 
+	var cErr *C.char
 	cStarts := make([]*C.char, len(ranges))
 	cLimits := make([]*C.char, len(ranges))
 	cStartLens := make([]C.size_t, len(ranges))
@@ -456,7 +710,11 @@ func (db *DB) GetApproximateSizes(ranges []Range) []uint64 {
 		&cStartLens[0],
 		&cLimits[0],
 		&cLimitLens[0],
-		(*C.uint64_t)(&sizes[0]))
+		(*C.uint64_t)(&sizes[0]),
+		&cErr)
+	if cErr != nil {
+		C.free(unsafe.Pointer(cErr))
+	}
 
 	return sizes
 }
@@ -465,13 +723,14 @@ func (db *DB) GetApproximateSizes(ranges []Range) []uint64 {
 // space used by one or more key ranges in the column family.
 //
 // The keys counted will begin at Range.Start and end on the key before
-// Range.Limit.
+// Range.Limit. The sizes are 0 if RocksDB fails to compute them.
 func (db *DB) GetApproximateSizesCF(cf *ColumnFamilyHandle, ranges []Range) []uint64 {
 	sizes := make([]uint64, len(ranges))
 	if len(ranges) == 0 {
 		return sizes
 	}
 
+	var cErr *C.char
 	cStarts := make([]*C.char, len(ranges))
 	cLimits := make([]*C.char, len(ranges))
 	cStartLens := make([]C.size_t, len(ranges))
@@ -491,22 +750,34 @@ func (db *DB) GetApproximateSizesCF(cf *ColumnFamilyHandle, ranges []Range) []ui
 		&cStartLens[0],
 		&cLimits[0],
 		&cLimitLens[0],
-		(*C.uint64_t)(&sizes[0]))
+		(*C.uint64_t)(&sizes[0]),
+		&cErr)
+	if cErr != nil {
+		C.free(unsafe.Pointer(cErr))
+	}
 
 	return sizes
 }
 
 // LiveFileMetadata is a metadata which is associated with each SST file.
 type LiveFileMetadata struct {
-	Name        string
-	Level       int
-	Size        int64
-	SmallestKey []byte
-	LargestKey  []byte
+	Name             string
+	ColumnFamilyName string
+	// Directory is the directory the file is stored in, Name is relative
+	// to it.
+	Directory     string
+	Level         int
+	Size          int64
+	SmallestKey   []byte
+	LargestKey    []byte
+	SmallestSeqno uint64
+	LargestSeqno  uint64
+	NumEntries    uint64
+	NumDeletions  uint64
 }
 
-// GetLiveFilesMetaData returns a list of all table files with their
-// level, start key and end key.
+// GetLiveFilesMetaData returns a list of the table files of all column
+// families with their level, start key and end key.
 func (db *DB) GetLiveFilesMetaData() []LiveFileMetadata {
 	lf := C.rocksdb_livefiles(db.c)
 	defer C.rocksdb_livefiles_destroy(lf)
@@ -516,8 +787,14 @@ func (db *DB) GetLiveFilesMetaData() []LiveFileMetadata {
 	for i := C.int(0); i < count; i++ {
 		var liveFile LiveFileMetadata
 		liveFile.Name = C.GoString(C.rocksdb_livefiles_name(lf, i))
+		liveFile.ColumnFamilyName = C.GoString(C.rocksdb_livefiles_column_family_name(lf, i))
+		liveFile.Directory = C.GoString(C.rocksdb_livefiles_directory(lf, i))
 		liveFile.Level = int(C.rocksdb_livefiles_level(lf, i))
 		liveFile.Size = int64(C.rocksdb_livefiles_size(lf, i))
+		liveFile.SmallestSeqno = uint64(C.rocksdb_livefiles_smallest_seqno(lf, i))
+		liveFile.LargestSeqno = uint64(C.rocksdb_livefiles_largest_seqno(lf, i))
+		liveFile.NumEntries = uint64(C.rocksdb_livefiles_entries(lf, i))
+		liveFile.NumDeletions = uint64(C.rocksdb_livefiles_deletions(lf, i))
 
 		var cSize C.size_t
 		key := C.rocksdb_livefiles_smallestkey(lf, i, &cSize)
@@ -530,6 +807,96 @@ func (db *DB) GetLiveFilesMetaData() []LiveFileMetadata {
 	return liveFiles
 }
 
+// ColumnFamilyMetadata describes the table files of a column family.
+type ColumnFamilyMetadata struct {
+	Name string
+	// Size is the total size of the files in bytes.
+	Size      uint64
+	FileCount int
+	// Levels contains all levels of the LSM tree, ordered by level.
+	Levels []LevelMetadata
+}
+
+// LevelMetadata describes the table files of one level.
+type LevelMetadata struct {
+	Level int
+	// Size is the total size of the files in bytes.
+	Size  uint64
+	Files []SstFileMetadata
+}
+
+// SstFileMetadata describes a table file.
+type SstFileMetadata struct {
+	// Name is the name of the file relative to Directory.
+	Name        string
+	Directory   string
+	Size        uint64
+	SmallestKey []byte
+	LargestKey  []byte
+}
+
+// GetColumnFamilyMetadata returns the table files of the column family,
+// grouped by level. If cf is nil the default column family is used.
+func (db *DB) GetColumnFamilyMetadata(cf *ColumnFamilyHandle) *ColumnFamilyMetadata {
+	var cMeta *C.rocksdb_column_family_metadata_t
+	if cf == nil {
+		cMeta = C.rocksdb_get_column_family_metadata(db.c)
+	} else {
+		cMeta = C.rocksdb_get_column_family_metadata_cf(db.c, cf.c)
+	}
+	defer C.rocksdb_column_family_metadata_destroy(cMeta)
+
+	cName := C.rocksdb_column_family_metadata_get_name(cMeta)
+	defer C.free(unsafe.Pointer(cName))
+	meta := &ColumnFamilyMetadata{
+		Name:      C.GoString(cName),
+		Size:      uint64(C.rocksdb_column_family_metadata_get_size(cMeta)),
+		FileCount: int(C.rocksdb_column_family_metadata_get_file_count(cMeta)),
+		Levels:    make([]LevelMetadata, int(C.rocksdb_column_family_metadata_get_level_count(cMeta))),
+	}
+	for i := range meta.Levels {
+		cLevel := C.rocksdb_column_family_metadata_get_level_metadata(cMeta, C.size_t(i))
+		meta.Levels[i] = newLevelMetadata(cLevel)
+		C.rocksdb_level_metadata_destroy(cLevel)
+	}
+	return meta
+}
+
+func newLevelMetadata(c *C.rocksdb_level_metadata_t) LevelMetadata {
+	level := LevelMetadata{
+		Level: int(C.rocksdb_level_metadata_get_level(c)),
+		Size:  uint64(C.rocksdb_level_metadata_get_size(c)),
+		Files: make([]SstFileMetadata, int(C.rocksdb_level_metadata_get_file_count(c))),
+	}
+	for i := range level.Files {
+		cFile := C.rocksdb_level_metadata_get_sst_file_metadata(c, C.size_t(i))
+		level.Files[i] = newSstFileMetadata(cFile)
+		C.rocksdb_sst_file_metadata_destroy(cFile)
+	}
+	return level
+}
+
+func newSstFileMetadata(c *C.rocksdb_sst_file_metadata_t) SstFileMetadata {
+	cName := C.rocksdb_sst_file_metadata_get_relative_filename(c)
+	defer C.free(unsafe.Pointer(cName))
+	cDir := C.rocksdb_sst_file_metadata_get_directory(c)
+	defer C.free(unsafe.Pointer(cDir))
+	var cLen C.size_t
+	cSmallest := C.rocksdb_sst_file_metadata_get_smallestkey(c, &cLen)
+	defer C.free(unsafe.Pointer(cSmallest))
+	smallest := C.GoBytes(unsafe.Pointer(cSmallest), C.int(cLen))
+	cLargest := C.rocksdb_sst_file_metadata_get_largestkey(c, &cLen)
+	defer C.free(unsafe.Pointer(cLargest))
+	largest := C.GoBytes(unsafe.Pointer(cLargest), C.int(cLen))
+	return SstFileMetadata{
+		Name:        C.GoString(cName),
+		Directory:   C.GoString(cDir),
+		Size:        uint64(C.rocksdb_sst_file_metadata_get_size(c)),
+		SmallestKey: smallest,
+		LargestKey:  largest,
+	}
+}
+
 // CompactRange runs a manual compaction on the Range of keys given. This is
 // not likely to be needed for typical usage.
 func (db *DB) CompactRange(r Range) {
@@ -546,13 +913,79 @@ func (db *DB) CompactRangeCF(cf *ColumnFamilyHandle, r Range) {
 	C.rocksdb_compact_range_cf(db.c, cf.c, cStart, C.size_t(len(r.Start)), cLimit, C.size_t(len(r.Limit)))
 }
 
+// CompactRangeOpt runs a manual compaction on the Range of keys given with
+// the options given and waits for it to finish. A nil Start or Limit means
+// the range is unbounded on that side.
+func (db *DB) CompactRangeOpt(opts *CompactRangeOptions, r Range) error {
+	return db.compactRangeOpt(nil, opts, r)
+}
+
+// CompactRangeCFOpt runs a manual compaction on the Range of keys given on
+// the given column family with the options given. A nil Start or Limit
+// means the range is unbounded on that side.
+func (db *DB) CompactRangeCFOpt(cf *ColumnFamilyHandle, opts *CompactRangeOptions, r Range) error {
+	return db.compactRangeOpt(cf.c, opts, r)
+}
+
+func (db *DB) compactRangeOpt(cf *C.rocksdb_column_family_handle_t, opts *CompactRangeOptions, r Range) error {
+	var (
+		cErr   *C.char
+		cStart *C.char
+		cLimit *C.char
+	)
+	// byteToChar maps empty keys to nil, which would make an empty Start
+	// or Limit unbounded.
+	if r.Start != nil {
+		cStart = C.CString(string(r.Start))
+		defer C.free(unsafe.Pointer(cStart))
+	}
+	if r.Limit != nil {
+		cLimit = C.CString(string(r.Limit))
+		defer C.free(unsafe.Pointer(cLimit))
+	}
+	canceled := opts.start()
+	defer opts.finish(canceled)
+	C.gorocksdb_compact_range_opt(db.c, cf, opts.c, canceled, cStart, C.size_t(len(r.Start)), cLimit, C.size_t(len(r.Limit)), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newWriteError(C.GoString(cErr))
+	}
+	return nil
+}
+
 // Flush triggers a manuel flush for the database.
 func (db *DB) Flush(opts *FlushOptions) error {
 	var cErr *C.char
 	C.rocksdb_flush(db.c, opts.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// FlushWAL writes the buffered WAL entries to the WAL file. If sync is
+// true the file is synced as well, which makes all previous writes
+// durable. This is needed if Options.SetManualWALFlush is set.
+func (db *DB) FlushWAL(sync bool) error {
+	var cErr *C.char
+	C.rocksdb_flush_wal(db.c, boolToChar(sync), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newWriteError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// SyncWAL syncs the WAL file, which makes all previous writes which were
+// written without WriteOptions.SetSync durable. Unlike FlushWAL it does
+// not write the entries buffered with Options.SetManualWALFlush.
+func (db *DB) SyncWAL() error {
+	var cErr *C.char
+	C.gorocksdb_sync_wal(db.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -568,10 +1001,41 @@ func (db *DB) DisableFileDeletions() error {
 	return nil
 }
 
-// EnableFileDeletions enables file deletions for the database.
+// EnableFileDeletions enables file deletions for the database. Each call
+// undoes one DisableFileDeletions call.
+//
+// The force argument is ignored. RocksDB 9 removed the forced mode, which
+// enabled file deletions regardless of the number of DisableFileDeletions
+// calls.
 func (db *DB) EnableFileDeletions(force bool) error {
 	var cErr *C.char
-	C.rocksdb_enable_file_deletions(db.c, boolToChar(force), &cErr)
+	C.rocksdb_enable_file_deletions(db.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return errors.New(C.GoString(cErr))
+	}
+	return nil
+}
+
+// PauseBackgroundWork waits for the running flushes and compactions to
+// finish and prevents new ones from starting until ContinueBackgroundWork
+// is called. Writes stall once the memtables are full.
+func (db *DB) PauseBackgroundWork() error {
+	var cErr *C.char
+	C.gorocksdb_pause_background_work(db.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return errors.New(C.GoString(cErr))
+	}
+	return nil
+}
+
+// ContinueBackgroundWork resumes the flushes and compactions paused by
+// PauseBackgroundWork. Every call to PauseBackgroundWork must be matched by
+// a call to ContinueBackgroundWork.
+func (db *DB) ContinueBackgroundWork() error {
+	var cErr *C.char
+	C.gorocksdb_continue_background_work(db.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
 		return errors.New(C.GoString(cErr))
@@ -579,18 +1043,93 @@ func (db *DB) EnableFileDeletions(force bool) error {
 	return nil
 }
 
+// CancelAllBackgroundWork stops all flushes and compactions permanently,
+// e.g. to shut down quickly. If wait is true it waits for the running jobs
+// to finish or abort. Only Close should be called on the database
+// afterwards.
+func (db *DB) CancelAllBackgroundWork(wait bool) {
+	C.rocksdb_cancel_all_background_work(db.c, boolToChar(wait))
+}
+
+// WaitForCompact waits until all flushes and compactions which are running
+// or scheduled have finished, e.g. after a bulk load.
+func (db *DB) WaitForCompact(opts *WaitForCompactOptions) error {
+	var cErr *C.char
+	C.rocksdb_wait_for_compact(db.c, opts.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newWriteError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// DisableManualCompaction cancels the running manual compactions and makes
+// new ones fail until EnableManualCompaction is called.
+func (db *DB) DisableManualCompaction() {
+	C.rocksdb_disable_manual_compaction(db.c)
+}
+
+// EnableManualCompaction allows manual compactions again after
+// DisableManualCompaction.
+func (db *DB) EnableManualCompaction() {
+	C.rocksdb_enable_manual_compaction(db.c)
+}
+
+// Resume recovers the database from a background error, e.g. once disk
+// space has been freed after an *OutOfSpaceError. The database returns to
+// read-write mode if the recovery succeeds.
+func (db *DB) Resume() error {
+	var cErr *C.char
+	C.gorocksdb_resume(db.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newWriteError(C.GoString(cErr))
+	}
+	return nil
+}
+
 // DeleteFile deletes the file name from the db directory and update the internal state to
 // reflect that. Supports deletion of sst and log files only. 'name' must be
 // path relative to the db directory. eg. 000001.sst, /archive/000003.log.
 func (db *DB) DeleteFile(name string) {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
-	C.rocksdb_delete_file(db.c, cName)
+	C.gorocksdb_delete_file(db.c, cName)
 }
 
 // Close closes the database.
+//
+// Close blocks until all iterators and snapshots created from the database
+// have been released. The column family handles owned by the database are
+// destroyed and must not be used afterwards. Unlike iterators and
+// snapshots, they are not reference counted: the database owns them and
+// ColumnFamilyHandle.Destroy does nothing for them, so there is nothing
+// for Close to wait for. Closing a closed database does nothing.
 func (db *DB) Close() {
+	if db.c == nil {
+		return
+	}
+	db.refs.closeWhenEmpty(-1)
+	db.cfs.destroyAll()
 	C.rocksdb_close(db.c)
+	db.c = nil
+}
+
+// CloseWithTimeout closes the database like Close but waits at most timeout
+// for outstanding iterators and snapshots to be released. If they are not
+// released in time a *LeakedObjectsError listing them is returned and the
+// database is left open. Closing a closed database does nothing.
+func (db *DB) CloseWithTimeout(timeout time.Duration) error {
+	if db.c == nil {
+		return nil
+	}
+	if err := db.refs.closeWhenEmpty(timeout); err != nil {
+		return err
+	}
+	db.cfs.destroyAll()
+	C.rocksdb_close(db.c)
+	db.c = nil
+	return nil
 }
 
 // DestroyDb removes a database entirely, removing everything from the
@@ -0,0 +1,210 @@
+package gorocksdb
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LevelStats describes the files of one level of the LSM tree.
+type LevelStats struct {
+	Level    int
+	NumFiles int
+	// SizeMB is the total size of the files in MB, rounded to whole MB.
+	SizeMB float64
+}
+
+// LevelCompactionStats holds the compaction statistics of one level.
+type LevelCompactionStats struct {
+	Level          int
+	NumFiles       int
+	CompactedFiles int
+	SizeBytes      uint64
+	Score          float64
+	// ReadGB is the data read by compactions, RnGB and Rnp1GB split it
+	// into the data read from this level and from the next level.
+	ReadGB float64
+	RnGB   float64
+	Rnp1GB float64
+	// WriteGB is the data written by compactions, WnewGB the part of it
+	// which was not read from the next level.
+	WriteGB  float64
+	WnewGB   float64
+	MovedGB  float64
+	WriteAmp float64
+	// ReadMBps and WriteMBps are the compaction throughput.
+	ReadMBps  float64
+	WriteMBps float64
+	// CompSec is the total time spent in compactions, CompMergeCPUSec the
+	// CPU time spent merging.
+	CompSec         float64
+	CompMergeCPUSec float64
+	CompCount       uint64
+	AvgSec          float64
+	KeyIn           uint64
+	KeyDrop         uint64
+	ReadBlobGB      float64
+	WriteBlobGB     float64
+}
+
+// CompactionStats holds the compaction statistics of a column family.
+type CompactionStats struct {
+	// Levels contains the levels which have files or have been compacted,
+	// ordered by level.
+	Levels []LevelCompactionStats
+	// Sum is the total over all levels. Its Level is -1.
+	Sum LevelCompactionStats
+}
+
+// LevelStats returns the number of files and their size for each level of
+// the default column family.
+func (db *DB) LevelStats() ([]LevelStats, error) {
+	return parseLevelStats(db.GetProperty(PropertyLevelStats))
+}
+
+// CompactionStats returns the compaction statistics of the column family.
+// If cf is nil the default column family is used.
+func (db *DB) CompactionStats(cf *ColumnFamilyHandle) (*CompactionStats, error) {
+	var (
+		props map[string]string
+		ok    bool
+	)
+	if cf == nil {
+		props, ok = db.GetMapProperty(PropertyCFStats)
+	} else {
+		props, ok = db.GetMapPropertyCF(PropertyCFStats, cf)
+	}
+	if !ok {
+		return nil, errors.New("gorocksdb: property " + PropertyCFStats + " not available")
+	}
+	return parseCompactionStats(props)
+}
+
+// parseLevelStats parses the output of the rocksdb.levelstats property:
+//
+//	Level Files Size(MB)
+//	--------------------
+//	  0        1        0
+//	  1        0        0
+func parseLevelStats(s string) ([]LevelStats, error) {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], "Level") {
+		return nil, fmt.Errorf("gorocksdb: unexpected %s output: %q", PropertyLevelStats, s)
+	}
+	stats := make([]LevelStats, 0, len(lines)-2)
+	for _, line := range lines[2:] {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("gorocksdb: unexpected %s line: %q", PropertyLevelStats, line)
+		}
+		level, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		numFiles, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, LevelStats{Level: level, NumFiles: numFiles, SizeMB: size})
+	}
+	return stats, nil
+}
+
+// parseCompactionStats parses the "compaction.<level>.<stat>" entries of
+// the rocksdb.cfstats map property, e.g. "compaction.L0.WriteAmp". The
+// level is "L<n>" or "Sum"; unknown stats are ignored.
+func parseCompactionStats(props map[string]string) (*CompactionStats, error) {
+	stats := &CompactionStats{Sum: LevelCompactionStats{Level: -1}}
+	levels := make(map[int]*LevelCompactionStats)
+	maxLevel := -1
+	for key, value := range props {
+		if !strings.HasPrefix(key, "compaction.") {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(key, "compaction."), ".", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		var ls *LevelCompactionStats
+		if parts[0] == "Sum" {
+			ls = &stats.Sum
+		} else if strings.HasPrefix(parts[0], "L") {
+			level, err := strconv.Atoi(parts[0][1:])
+			if err != nil {
+				continue
+			}
+			if ls = levels[level]; ls == nil {
+				ls = &LevelCompactionStats{Level: level}
+				levels[level] = ls
+			}
+			if level > maxLevel {
+				maxLevel = level
+			}
+		} else {
+			continue
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("gorocksdb: invalid value for %s: %q", key, value)
+		}
+		setCompactionStat(ls, parts[1], v)
+	}
+	for level := 0; level <= maxLevel; level++ {
+		if ls := levels[level]; ls != nil {
+			stats.Levels = append(stats.Levels, *ls)
+		}
+	}
+	return stats, nil
+}
+
+func setCompactionStat(ls *LevelCompactionStats, name string, v float64) {
+	switch name {
+	case "NumFiles":
+		ls.NumFiles = int(v)
+	case "CompactedFiles":
+		ls.CompactedFiles = int(v)
+	case "SizeBytes":
+		ls.SizeBytes = uint64(v)
+	case "Score":
+		ls.Score = v
+	case "ReadGB":
+		ls.ReadGB = v
+	case "RnGB":
+		ls.RnGB = v
+	case "Rnp1GB":
+		ls.Rnp1GB = v
+	case "WriteGB":
+		ls.WriteGB = v
+	case "WnewGB":
+		ls.WnewGB = v
+	case "MovedGB":
+		ls.MovedGB = v
+	case "WriteAmp":
+		ls.WriteAmp = v
+	case "ReadMBps":
+		ls.ReadMBps = v
+	case "WriteMBps":
+		ls.WriteMBps = v
+	case "CompSec":
+		ls.CompSec = v
+	case "CompMergeCPU":
+		ls.CompMergeCPUSec = v
+	case "CompCount":
+		ls.CompCount = uint64(v)
+	case "AvgSec":
+		ls.AvgSec = v
+	case "KeyIn":
+		ls.KeyIn = uint64(v)
+	case "KeyDrop":
+		ls.KeyDrop = uint64(v)
+	case "RblobGB":
+		ls.ReadBlobGB = v
+	case "WblobGB":
+		ls.WriteBlobGB = v
+	}
+}
@@ -0,0 +1,188 @@
+package gorocksdb
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestParseLevelStats(t *testing.T) {
+	const output = "Level Files Size(MB)\n" +
+		"--------------------\n" +
+		"  0        2        1\n" +
+		"  1        0        0\n" +
+		"  2        0        0\n" +
+		"  3        0        0\n" +
+		"  4        0        0\n" +
+		"  5        0        0\n" +
+		"  6        3      125\n"
+
+	stats, err := parseLevelStats(output)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(stats), 7)
+	ensure.DeepEqual(t, stats[0], LevelStats{Level: 0, NumFiles: 2, SizeMB: 1})
+	ensure.DeepEqual(t, stats[6], LevelStats{Level: 6, NumFiles: 3, SizeMB: 125})
+
+	_, err = parseLevelStats("")
+	ensure.NotNil(t, err)
+	_, err = parseLevelStats("Level Files Size(MB)\n--------------------\n  0 x 1\n")
+	ensure.NotNil(t, err)
+}
+
+func TestParseCompactionStats(t *testing.T) {
+	// rocksdb.cfstats of a database with two flushes compacted to L6 and
+	// a third flush left in L0.
+	props := map[string]string{
+		"compaction.L0.AvgSec":                            "0.000645",
+		"compaction.L0.CompCount":                         "3.000000",
+		"compaction.L0.CompMergeCPU":                      "0.001210",
+		"compaction.L0.CompSec":                           "0.001934",
+		"compaction.L0.CompactedFiles":                    "0.000000",
+		"compaction.L0.KeyDrop":                           "0.000000",
+		"compaction.L0.KeyIn":                             "0.000000",
+		"compaction.L0.MovedGB":                           "0.000000",
+		"compaction.L0.NumFiles":                          "1.000000",
+		"compaction.L0.RblobGB":                           "0.000000",
+		"compaction.L0.ReadGB":                            "0.000000",
+		"compaction.L0.ReadMBps":                          "0.000000",
+		"compaction.L0.RnGB":                              "0.000000",
+		"compaction.L0.Rnp1GB":                            "0.000000",
+		"compaction.L0.Score":                             "0.250000",
+		"compaction.L0.SizeBytes":                         "1046.000000",
+		"compaction.L0.WblobGB":                           "0.000000",
+		"compaction.L0.WnewGB":                            "0.000003",
+		"compaction.L0.WriteAmp":                          "0.000000",
+		"compaction.L0.WriteGB":                           "0.000003",
+		"compaction.L0.WriteMBps":                         "1.547378",
+		"compaction.L6.AvgSec":                            "0.001187",
+		"compaction.L6.CompCount":                         "1.000000",
+		"compaction.L6.CompMergeCPU":                      "0.000912",
+		"compaction.L6.CompSec":                           "0.001187",
+		"compaction.L6.CompactedFiles":                    "0.000000",
+		"compaction.L6.KeyDrop":                           "3.000000",
+		"compaction.L6.KeyIn":                             "6.000000",
+		"compaction.L6.MovedGB":                           "0.000000",
+		"compaction.L6.NumFiles":                          "1.000000",
+		"compaction.L6.RblobGB":                           "0.000000",
+		"compaction.L6.ReadGB":                            "0.000002",
+		"compaction.L6.ReadMBps":                          "1.680781",
+		"compaction.L6.RnGB":                              "0.000002",
+		"compaction.L6.Rnp1GB":                            "0.000000",
+		"compaction.L6.Score":                             "0.000004",
+		"compaction.L6.SizeBytes":                         "1067.000000",
+		"compaction.L6.WblobGB":                           "0.000000",
+		"compaction.L6.WnewGB":                            "0.000001",
+		"compaction.L6.WriteAmp":                          "0.510038",
+		"compaction.L6.WriteGB":                           "0.000001",
+		"compaction.L6.WriteMBps":                         "0.857262",
+		"compaction.Sum.AvgSec":                           "0.000780",
+		"compaction.Sum.CompCount":                        "4.000000",
+		"compaction.Sum.CompMergeCPU":                     "0.002122",
+		"compaction.Sum.CompSec":                          "0.003121",
+		"compaction.Sum.CompactedFiles":                   "0.000000",
+		"compaction.Sum.KeyDrop":                          "3.000000",
+		"compaction.Sum.KeyIn":                            "6.000000",
+		"compaction.Sum.MovedGB":                          "0.000000",
+		"compaction.Sum.NumFiles":                         "2.000000",
+		"compaction.Sum.RblobGB":                          "0.000000",
+		"compaction.Sum.ReadGB":                           "0.000002",
+		"compaction.Sum.ReadMBps":                         "0.639246",
+		"compaction.Sum.RnGB":                             "0.000002",
+		"compaction.Sum.Rnp1GB":                           "0.000000",
+		"compaction.Sum.Score":                            "0.000000",
+		"compaction.Sum.SizeBytes":                        "2113.000000",
+		"compaction.Sum.WblobGB":                          "0.000000",
+		"compaction.Sum.WnewGB":                           "0.000004",
+		"compaction.Sum.WriteAmp":                         "1.340025",
+		"compaction.Sum.WriteGB":                          "0.000004",
+		"compaction.Sum.WriteMBps":                        "1.284909",
+		"io_stalls.level0_numfiles":                       "0",
+		"io_stalls.level0_numfiles_with_compaction":       "0",
+		"io_stalls.level0_slowdown":                       "0",
+		"io_stalls.level0_slowdown_with_compaction":       "0",
+		"io_stalls.memtable_compaction":                   "0",
+		"io_stalls.memtable_slowdown":                     "0",
+		"io_stalls.slowdown_for_pending_compaction_bytes": "0",
+		"io_stalls.stop_for_pending_compaction_bytes":     "0",
+		"io_stalls.total_slowdown":                        "0",
+		"io_stalls.total_stop":                            "0",
+	}
+
+	stats, err := parseCompactionStats(props)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, len(stats.Levels), 2)
+
+	ensure.DeepEqual(t, stats.Levels[0], LevelCompactionStats{
+		Level:           0,
+		NumFiles:        1,
+		SizeBytes:       1046,
+		Score:           0.25,
+		WriteGB:         0.000003,
+		WnewGB:          0.000003,
+		WriteMBps:       1.547378,
+		CompSec:         0.001934,
+		CompMergeCPUSec: 0.00121,
+		CompCount:       3,
+		AvgSec:          0.000645,
+	})
+	ensure.DeepEqual(t, stats.Levels[1], LevelCompactionStats{
+		Level:           6,
+		NumFiles:        1,
+		SizeBytes:       1067,
+		Score:           0.000004,
+		ReadGB:          0.000002,
+		RnGB:            0.000002,
+		WriteGB:         0.000001,
+		WnewGB:          0.000001,
+		WriteAmp:        0.510038,
+		ReadMBps:        1.680781,
+		WriteMBps:       0.857262,
+		CompSec:         0.001187,
+		CompMergeCPUSec: 0.000912,
+		CompCount:       1,
+		AvgSec:          0.001187,
+		KeyIn:           6,
+		KeyDrop:         3,
+	})
+	ensure.DeepEqual(t, stats.Sum.Level, -1)
+	ensure.DeepEqual(t, stats.Sum.NumFiles, 2)
+	ensure.DeepEqual(t, stats.Sum.SizeBytes, uint64(2113))
+	ensure.DeepEqual(t, stats.Sum.WriteAmp, 1.340025)
+	ensure.DeepEqual(t, stats.Sum.CompCount, uint64(4))
+	ensure.DeepEqual(t, stats.Sum.KeyDrop, uint64(3))
+
+	// the blob stats are parsed as well
+	props["compaction.L6.RblobGB"] = "0.500000"
+	props["compaction.L6.WblobGB"] = "0.250000"
+	stats, err = parseCompactionStats(props)
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, stats.Levels[1].ReadBlobGB, 0.5)
+	ensure.DeepEqual(t, stats.Levels[1].WriteBlobGB, 0.25)
+
+	_, err = parseCompactionStats(map[string]string{"compaction.L0.NumFiles": "x"})
+	ensure.NotNil(t, err)
+}
+
+func TestDBLevelAndCompactionStats(t *testing.T) {
+	db := newTestDB(t, "TestDBLevelAndCompactionStats", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("key"), []byte("val")))
+	ensure.Nil(t, db.Flush(fo))
+
+	levels, err := db.LevelStats()
+	ensure.Nil(t, err)
+	ensure.True(t, len(levels) > 0)
+	ensure.DeepEqual(t, levels[0].NumFiles, 1)
+
+	stats, err := db.CompactionStats(nil)
+	ensure.Nil(t, err)
+	ensure.True(t, len(stats.Levels) > 0)
+	ensure.DeepEqual(t, stats.Levels[0].Level, 0)
+	ensure.DeepEqual(t, stats.Levels[0].NumFiles, 1)
+	ensure.DeepEqual(t, stats.Sum.NumFiles, 1)
+}
@@ -1,8 +1,13 @@
 package gorocksdb
 
 import (
+	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/facebookgo/ensure"
 )
@@ -61,3 +66,299 @@ func newTestDB(t *testing.T, name string, applyOpts func(opts *Options)) *DB {
 
 	return db
 }
+
+// walSize returns the total size of the WAL files in dir.
+func walSize(t *testing.T, dir string) int64 {
+	files, err := ioutil.ReadDir(dir)
+	ensure.Nil(t, err)
+	var size int64
+	for _, f := range files {
+		if strings.HasSuffix(f.Name(), ".log") {
+			size += f.Size()
+		}
+	}
+	return size
+}
+
+// copyDBFiles copies the files of the database in dir to a new directory.
+func copyDBFiles(t *testing.T, dir string) string {
+	copyDir, err := ioutil.TempDir("", "gorocksdb-copy")
+	ensure.Nil(t, err)
+	files, err := ioutil.ReadDir(dir)
+	ensure.Nil(t, err)
+	for _, f := range files {
+		if f.IsDir() || f.Name() == "LOCK" {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		ensure.Nil(t, err)
+		ensure.Nil(t, ioutil.WriteFile(filepath.Join(copyDir, f.Name()), data, 0644))
+	}
+	return copyDir
+}
+
+func TestDBCloseWaitsForIterator(t *testing.T) {
+	db := newTestDB(t, "TestDBCloseWaitsForIterator", nil)
+
+	iter := db.NewIterator(NewDefaultReadOptions())
+	closed := make(chan struct{})
+	go func() {
+		db.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		t.Fatal("db closed while an iterator was still open")
+	case <-time.After(50 * time.Millisecond):
+	}
+	iter.Close()
+	<-closed
+}
+
+func TestDBCloseWithTimeout(t *testing.T) {
+	db := newTestDB(t, "TestDBCloseWithTimeout", nil)
+
+	iter := db.NewIterator(NewDefaultReadOptions())
+	snap := db.NewSnapshot()
+	err := db.CloseWithTimeout(10 * time.Millisecond)
+	leaked, ok := err.(*LeakedObjectsError)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, leaked.Objects, []string{"iterator #1", "snapshot #2"})
+
+	iter.Close()
+	snap.Release()
+	ensure.Nil(t, db.CloseWithTimeout(time.Second))
+
+	// closing again does nothing
+	ensure.Nil(t, db.CloseWithTimeout(time.Second))
+	db.Close()
+}
+
+func TestDBSetOptions(t *testing.T) {
+	db := newTestDB(t, "TestDBSetOptions", nil)
+	defer db.Close()
+
+	guideOpts := NewDefaultOptions()
+	defer guideOpts.Destroy()
+	cf, err := db.CreateColumnFamily(guideOpts, "guide")
+	ensure.Nil(t, err)
+
+	ensure.Nil(t, db.SetOptions(map[string]string{
+		"write_buffer_size":              "131072",
+		"level0_slowdown_writes_trigger": "30",
+		"disable_auto_compactions":       "true",
+	}))
+	ensure.NotNil(t, db.SetOptions(map[string]string{"num_levels": "3"}))
+	ensure.Nil(t, db.SetOptionsCF(cf, map[string]string{"write_buffer_size": "262144"}))
+	ensure.NotNil(t, db.SetOptionsCF(cf, map[string]string{"num_levels": "3"}))
+
+	ensure.Nil(t, db.SetDBOptions(map[string]string{"max_background_jobs": "4"}))
+	ensure.NotNil(t, db.SetDBOptions(map[string]string{"create_if_missing": "false"}))
+
+	// the applied options are written to the OPTIONS file
+	dbOpts, cfOpts := latestOptions(t, db.Name())
+	ensure.DeepEqual(t, dbOpts["max_background_jobs"], "4")
+	ensure.DeepEqual(t, cfOpts["default"]["write_buffer_size"], "131072")
+	ensure.DeepEqual(t, cfOpts["default"]["level0_slowdown_writes_trigger"], "30")
+	ensure.DeepEqual(t, cfOpts["default"]["disable_auto_compactions"], "true")
+	ensure.DeepEqual(t, cfOpts["guide"]["write_buffer_size"], "262144")
+	ensure.DeepEqual(t, cfOpts["guide"]["disable_auto_compactions"], "false")
+}
+
+// latestOptions returns the database options and the options of each
+// column family from the latest OPTIONS file in dir.
+func latestOptions(t *testing.T, dir string) (map[string]string, map[string]map[string]string) {
+	dbOpts, names, cfOpts, err := LoadLatestOptions(dir)
+	ensure.Nil(t, err)
+	defer dbOpts.Destroy()
+	dbMap, err := dbOpts.ToMap()
+	ensure.Nil(t, err)
+	cfMaps := make(map[string]map[string]string, len(names))
+	for i, name := range names {
+		cfMaps[name], err = cfOpts[i].ToMap()
+		ensure.Nil(t, err)
+		cfOpts[i].Destroy()
+	}
+	return dbMap, cfMaps
+}
+
+func TestDBGetIntProperty(t *testing.T) {
+	db := newTestDB(t, "TestDBGetIntProperty", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val")))
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("val")))
+
+	v, ok := db.GetIntProperty(PropertyNumEntriesActiveMemTable)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, v, uint64(2))
+	v, ok = db.GetAggregatedIntProperty(PropertyNumEntriesActiveMemTable)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, v, uint64(2))
+
+	_, ok = db.GetIntProperty(PropertyStats)
+	ensure.False(t, ok)
+	_, ok = db.GetIntProperty("rocksdb.unknown-property")
+	ensure.False(t, ok)
+}
+
+func TestDBGetMapProperty(t *testing.T) {
+	db := newTestDB(t, "TestDBGetMapProperty", nil)
+	defer db.Close()
+
+	props, ok := db.GetMapProperty(PropertyCFStats)
+	ensure.True(t, ok)
+	ensure.True(t, len(props) > 0)
+
+	_, ok = db.GetMapProperty("rocksdb.unknown-property")
+	ensure.False(t, ok)
+}
+
+func TestDBCompactRangeOpt(t *testing.T) {
+	db := newTestDB(t, "TestDBCompactRangeOpt", func(opts *Options) {
+		opts.SetDisableAutoCompactions(true)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	for i := 0; i < 3; i++ {
+		ensure.Nil(t, db.Put(wo, []byte("key"), []byte{byte(i)}))
+		ensure.Nil(t, db.Flush(fo))
+	}
+	ensure.DeepEqual(t, db.GetProperty(PropertyNumFilesAtLevelPrefix+"0"), "3")
+
+	opts := NewDefaultCompactRangeOptions()
+	defer opts.Destroy()
+	opts.SetExclusiveManualCompaction(true)
+	opts.SetChangeLevel(true)
+	opts.SetTargetLevel(1)
+	opts.SetBottommostLevelCompaction(BottommostLevelCompactionForce)
+	ensure.Nil(t, db.CompactRangeOpt(opts, Range{nil, nil}))
+	ensure.DeepEqual(t, db.GetProperty(PropertyNumFilesAtLevelPrefix+"0"), "0")
+	ensure.DeepEqual(t, db.GetProperty(PropertyNumFilesAtLevelPrefix+"1"), "1")
+}
+
+func TestDBCompactRangeOptCancel(t *testing.T) {
+	var (
+		once    sync.Once
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	db := newTestDB(t, "TestDBCompactRangeOptCancel", func(opts *Options) {
+		opts.SetDisableAutoCompactions(true)
+		// block the compaction on its first key until it has been canceled
+		opts.SetCompactionFilter(&mockCompactionFilter{
+			filter: func(level int, key, val []byte) (bool, []byte) {
+				once.Do(func() {
+					close(started)
+					<-release
+				})
+				return false, nil
+			},
+		})
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 100; j++ {
+			ensure.Nil(t, db.Put(wo, []byte(fmt.Sprintf("key%03d", j)), []byte{byte(i)}))
+		}
+		ensure.Nil(t, db.Flush(fo))
+	}
+
+	opts := NewDefaultCompactRangeOptions()
+	defer opts.Destroy()
+	errc := make(chan error, 1)
+	go func() {
+		errc <- db.CompactRangeOpt(opts, Range{nil, nil})
+	}()
+	<-started
+	opts.Cancel()
+	close(release)
+	ensure.NotNil(t, <-errc)
+	ensure.DeepEqual(t, db.GetProperty(PropertyNumFilesAtLevelPrefix+"0"), "2")
+
+	// the cancellation only applies to the compaction which was running
+	ensure.Nil(t, db.CompactRangeOpt(opts, Range{nil, nil}))
+	ensure.DeepEqual(t, db.GetProperty(PropertyNumFilesAtLevelPrefix+"0"), "0")
+}
+
+func TestDBBackgroundWork(t *testing.T) {
+	db := newTestDB(t, "TestDBBackgroundWork", nil)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("key"), []byte("val")))
+
+	wfco := NewDefaultWaitForCompactOptions()
+	defer wfco.Destroy()
+	wfco.SetFlush(true)
+	wfco.SetAbortOnPause(true)
+	wfco.SetTimeout(10 * time.Second)
+
+	ensure.Nil(t, db.PauseBackgroundWork())
+	ensure.NotNil(t, db.WaitForCompact(wfco))
+	ensure.Nil(t, db.ContinueBackgroundWork())
+	ensure.Nil(t, db.WaitForCompact(wfco))
+	ensure.DeepEqual(t, db.GetProperty(PropertyNumFilesAtLevelPrefix+"0"), "1")
+
+	cro := NewDefaultCompactRangeOptions()
+	defer cro.Destroy()
+	db.DisableManualCompaction()
+	ensure.NotNil(t, db.CompactRangeOpt(cro, Range{nil, nil}))
+	db.EnableManualCompaction()
+	ensure.Nil(t, db.CompactRangeOpt(cro, Range{nil, nil}))
+
+	// there is no background error to recover from
+	ensure.Nil(t, db.Resume())
+
+	db.CancelAllBackgroundWork(true)
+}
+
+func TestDBFlushWAL(t *testing.T) {
+	var opts *Options
+	db := newTestDB(t, "TestDBFlushWAL", func(o *Options) {
+		o.SetManualWALFlush(true)
+		o.SetWALRecoveryMode(AbsoluteConsistencyRecovery)
+		opts = o
+	})
+	ensure.True(t, opts.GetManualWALFlush())
+	ensure.DeepEqual(t, opts.GetWALRecoveryMode(), AbsoluteConsistencyRecovery)
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	wo.SetNoSlowdown(true)
+	wo.SetLowPri(true)
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val1")))
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("val2")))
+
+	// the writes only reach the WAL file once it is flushed
+	before := walSize(t, db.Name())
+	ensure.Nil(t, db.FlushWAL(true))
+	ensure.True(t, walSize(t, db.Name()) > before)
+	ensure.Nil(t, db.SyncWAL())
+
+	// Close flushes the WAL as well, so recover from a copy of the files
+	// taken before, like after a crash.
+	dir := copyDBFiles(t, db.Name())
+	db.Close()
+	db, err := OpenDb(opts, dir)
+	ensure.Nil(t, err)
+	defer db.Close()
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	v, err := db.GetBytes(ro, []byte("key2"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v, []byte("val2"))
+}
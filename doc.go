@@ -60,8 +60,10 @@ your database.
 	opts.SetFilterPolicy(filter)
 	db, err := gorocksdb.OpenDb(opts, "/path/to/db")
 
-If you're using a custom comparator in your code, be aware you may have to
-make your own filter policy object.
+If you're using a custom comparator which ignores parts of the keys, be
+aware that the filters are built from the whole keys or their prefixes.
+RocksDB doesn't support custom filter policies, so you may have to go
+without a filter.
 
 This documentation is not a complete discussion of RocksDB. Please read the
 RocksDB documentation <http://rocksdb.org/> for information on its
@@ -1,6 +1,7 @@
-// !build embed
+// +build !embed
 
 package gorocksdb
 
+// #cgo CXXFLAGS: -std=c++17
 // #cgo LDFLAGS: -lrocksdb -lstdc++ -lm -lz -lbz2 -lsnappy
 import "C"
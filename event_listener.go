@@ -0,0 +1,303 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+import (
+	"time"
+	"unsafe"
+)
+
+// An EventListener is notified about flushes, compactions, table files,
+// write stalls and background errors of all databases opened with the
+// options it was added to.
+//
+// The callbacks are called from RocksDB's background threads, possibly
+// concurrently. They should return quickly and must not call back into
+// the database which triggered them. Embed NoopEventListener to implement
+// only some of the callbacks.
+type EventListener interface {
+	// OnFlushBegin is called before a memtable flush starts.
+	OnFlushBegin(info FlushJobInfo)
+
+	// OnFlushCompleted is called after a memtable flush finished.
+	OnFlushCompleted(info FlushJobInfo)
+
+	// OnCompactionBegin is called before a compaction starts. The stats
+	// of the info are not set yet.
+	OnCompactionBegin(info CompactionJobInfo)
+
+	// OnCompactionCompleted is called after a compaction finished, even if
+	// it failed.
+	OnCompactionCompleted(info CompactionJobInfo)
+
+	// OnTableFileCreated is called after a table file was created by a
+	// flush or compaction, even if the creation failed.
+	OnTableFileCreated(info TableFileCreationInfo)
+
+	// OnTableFileDeleted is called after a table file was deleted.
+	OnTableFileDeleted(info TableFileDeletionInfo)
+
+	// OnStallConditionsChanged is called when the write stall condition of
+	// a column family changes.
+	OnStallConditionsChanged(info WriteStallInfo)
+
+	// OnBackgroundError is called when a background operation failed and
+	// the database switched to read-only mode. If the disk space ran out err
+	// is an *OutOfSpaceError.
+	OnBackgroundError(reason BackgroundErrorReason, err error)
+}
+
+// NoopEventListener implements EventListener with callbacks which do
+// nothing.
+type NoopEventListener struct{}
+
+func (NoopEventListener) OnFlushBegin(info FlushJobInfo)                            {}
+func (NoopEventListener) OnFlushCompleted(info FlushJobInfo)                        {}
+func (NoopEventListener) OnCompactionBegin(info CompactionJobInfo)                  {}
+func (NoopEventListener) OnCompactionCompleted(info CompactionJobInfo)              {}
+func (NoopEventListener) OnTableFileCreated(info TableFileCreationInfo)             {}
+func (NoopEventListener) OnTableFileDeleted(info TableFileDeletionInfo)             {}
+func (NoopEventListener) OnStallConditionsChanged(info WriteStallInfo)              {}
+func (NoopEventListener) OnBackgroundError(reason BackgroundErrorReason, err error) {}
+
+// FlushJobInfo describes a memtable flush.
+type FlushJobInfo struct {
+	ColumnFamilyID   uint32
+	ColumnFamilyName string
+	// FilePath is the path of the table file the memtable is flushed to.
+	FilePath string
+	JobID    int
+	ThreadID uint64
+	// TriggeredWritesSlowdown and TriggeredWritesStop tell whether writes
+	// were slowed down or stopped because too many level 0 files existed
+	// when the flush started.
+	TriggeredWritesSlowdown bool
+	TriggeredWritesStop     bool
+	SmallestSeqno           uint64
+	LargestSeqno            uint64
+	// Reason describes why the flush was triggered, e.g. "Manual Flush".
+	Reason string
+	// NumEntries, FlushedBytes and Elapsed are only set in OnFlushCompleted.
+	// FlushedBytes is the size of the data blocks written to the table
+	// file and Elapsed the time since OnFlushBegin of the same job.
+	NumEntries   uint64
+	FlushedBytes uint64
+	Elapsed      time.Duration
+}
+
+// CompactionJobInfo describes a compaction.
+type CompactionJobInfo struct {
+	ColumnFamilyID   uint32
+	ColumnFamilyName string
+	// Err is the error the compaction failed with, if any.
+	Err            error
+	JobID          int
+	ThreadID       uint64
+	BaseInputLevel int
+	OutputLevel    int
+	InputFiles     []string
+	OutputFiles    []string
+	// Reason describes why the compaction was triggered, e.g.
+	// "ManualCompaction".
+	Reason           string
+	Elapsed          time.Duration
+	NumInputRecords  uint64
+	NumOutputRecords uint64
+	TotalInputBytes  uint64
+	TotalOutputBytes uint64
+}
+
+// TableFileCreationInfo describes the creation of a table file.
+type TableFileCreationInfo struct {
+	DBName           string
+	ColumnFamilyName string
+	FilePath         string
+	JobID            int
+	// Reason is "Flush", "Compaction", "Recovery" or "Misc".
+	Reason string
+	// Err is the error the creation failed with, if any.
+	Err          error
+	FileSize     uint64
+	NumEntries   uint64
+	NumDeletions uint64
+}
+
+// TableFileDeletionInfo describes the deletion of a table file.
+type TableFileDeletionInfo struct {
+	DBName   string
+	FilePath string
+	JobID    int
+	// Err is the error the deletion failed with, if any.
+	Err error
+}
+
+// WriteStallCondition describes whether writes to a column family are
+// delayed or stopped.
+type WriteStallCondition int
+
+const (
+	WriteStallNormal  = WriteStallCondition(0)
+	WriteStallDelayed = WriteStallCondition(1)
+	WriteStallStopped = WriteStallCondition(2)
+)
+
+// WriteStallInfo describes a change of the write stall condition.
+type WriteStallInfo struct {
+	ColumnFamilyName string
+	Cur              WriteStallCondition
+	Prev             WriteStallCondition
+}
+
+// BackgroundErrorReason is the operation a background error occurred in.
+type BackgroundErrorReason int
+
+const (
+	BackgroundErrorFlush              = BackgroundErrorReason(0)
+	BackgroundErrorCompaction         = BackgroundErrorReason(1)
+	BackgroundErrorWriteCallback      = BackgroundErrorReason(2)
+	BackgroundErrorMemTable           = BackgroundErrorReason(3)
+	BackgroundErrorManifestWrite      = BackgroundErrorReason(4)
+	BackgroundErrorFlushNoWAL         = BackgroundErrorReason(5)
+	BackgroundErrorManifestWriteNoWAL = BackgroundErrorReason(6)
+)
+
+// AddEventListener adds a listener which is notified about the events of
+// all databases opened with these options.
+func (opts *Options) AddEventListener(l EventListener) {
+	idx := registerEventListener(l)
+	C.gorocksdb_options_add_eventlistener(opts.c, C.uintptr_t(idx))
+}
+
+// Hold references to event listeners.
+var eventListeners = newRegistry()
+
+func registerEventListener(l EventListener) int {
+	return eventListeners.register(l)
+}
+
+func getEventListener(idx int) EventListener {
+	return eventListeners.lookup(idx).(EventListener)
+}
+
+func statusToError(cStatus *C.char) error {
+	if cStatus == nil {
+		return nil
+	}
+	return newWriteError(C.GoString(cStatus))
+}
+
+func cStringArray(cArr **C.char, n C.size_t) []string {
+	num := int(n)
+	strs := make([]string, num)
+	if num == 0 {
+		return strs
+	}
+	arr := (*[1 << 30]*C.char)(unsafe.Pointer(cArr))[:num:num]
+	for i, s := range arr {
+		strs[i] = C.GoString(s)
+	}
+	return strs
+}
+
+func newFlushJobInfo(c *C.gorocksdb_flush_job_info_t) FlushJobInfo {
+	return FlushJobInfo{
+		ColumnFamilyID:          uint32(c.cf_id),
+		ColumnFamilyName:        C.GoString(c.cf_name),
+		FilePath:                C.GoString(c.file_path),
+		JobID:                   int(c.job_id),
+		ThreadID:                uint64(c.thread_id),
+		TriggeredWritesSlowdown: charToBool(c.triggered_writes_slowdown),
+		TriggeredWritesStop:     charToBool(c.triggered_writes_stop),
+		SmallestSeqno:           uint64(c.smallest_seqno),
+		LargestSeqno:            uint64(c.largest_seqno),
+		Reason:                  C.GoString(c.reason),
+		NumEntries:              uint64(c.num_entries),
+		FlushedBytes:            uint64(c.data_size),
+		Elapsed:                 time.Duration(c.elapsed_micros) * time.Microsecond,
+	}
+}
+
+func newCompactionJobInfo(c *C.gorocksdb_compaction_job_info_t) CompactionJobInfo {
+	return CompactionJobInfo{
+		ColumnFamilyID:   uint32(c.cf_id),
+		ColumnFamilyName: C.GoString(c.cf_name),
+		Err:              statusToError(c.status),
+		JobID:            int(c.job_id),
+		ThreadID:         uint64(c.thread_id),
+		BaseInputLevel:   int(c.base_input_level),
+		OutputLevel:      int(c.output_level),
+		InputFiles:       cStringArray(c.input_files, c.num_input_files),
+		OutputFiles:      cStringArray(c.output_files, c.num_output_files),
+		Reason:           C.GoString(c.reason),
+		Elapsed:          time.Duration(c.elapsed_micros) * time.Microsecond,
+		NumInputRecords:  uint64(c.num_input_records),
+		NumOutputRecords: uint64(c.num_output_records),
+		TotalInputBytes:  uint64(c.total_input_bytes),
+		TotalOutputBytes: uint64(c.total_output_bytes),
+	}
+}
+
+//export gorocksdb_eventlistener_on_flush_begin
+func gorocksdb_eventlistener_on_flush_begin(idx int, cInfo *C.gorocksdb_flush_job_info_t) {
+	getEventListener(idx).OnFlushBegin(newFlushJobInfo(cInfo))
+}
+
+//export gorocksdb_eventlistener_on_flush_completed
+func gorocksdb_eventlistener_on_flush_completed(idx int, cInfo *C.gorocksdb_flush_job_info_t) {
+	getEventListener(idx).OnFlushCompleted(newFlushJobInfo(cInfo))
+}
+
+//export gorocksdb_eventlistener_on_compaction_begin
+func gorocksdb_eventlistener_on_compaction_begin(idx int, cInfo *C.gorocksdb_compaction_job_info_t) {
+	getEventListener(idx).OnCompactionBegin(newCompactionJobInfo(cInfo))
+}
+
+//export gorocksdb_eventlistener_on_compaction_completed
+func gorocksdb_eventlistener_on_compaction_completed(idx int, cInfo *C.gorocksdb_compaction_job_info_t) {
+	getEventListener(idx).OnCompactionCompleted(newCompactionJobInfo(cInfo))
+}
+
+//export gorocksdb_eventlistener_on_table_file_created
+func gorocksdb_eventlistener_on_table_file_created(idx int, cInfo *C.gorocksdb_table_file_creation_info_t) {
+	getEventListener(idx).OnTableFileCreated(TableFileCreationInfo{
+		DBName:           C.GoString(cInfo.db_name),
+		ColumnFamilyName: C.GoString(cInfo.cf_name),
+		FilePath:         C.GoString(cInfo.file_path),
+		JobID:            int(cInfo.job_id),
+		Reason:           C.GoString(cInfo.reason),
+		Err:              statusToError(cInfo.status),
+		FileSize:         uint64(cInfo.file_size),
+		NumEntries:       uint64(cInfo.num_entries),
+		NumDeletions:     uint64(cInfo.num_deletions),
+	})
+}
+
+//export gorocksdb_eventlistener_on_table_file_deleted
+func gorocksdb_eventlistener_on_table_file_deleted(idx int, cInfo *C.gorocksdb_table_file_deletion_info_t) {
+	getEventListener(idx).OnTableFileDeleted(TableFileDeletionInfo{
+		DBName:   C.GoString(cInfo.db_name),
+		FilePath: C.GoString(cInfo.file_path),
+		JobID:    int(cInfo.job_id),
+		Err:      statusToError(cInfo.status),
+	})
+}
+
+//export gorocksdb_eventlistener_on_stall_conditions_changed
+func gorocksdb_eventlistener_on_stall_conditions_changed(idx int, cInfo *C.gorocksdb_write_stall_info_t) {
+	getEventListener(idx).OnStallConditionsChanged(WriteStallInfo{
+		ColumnFamilyName: C.GoString(cInfo.cf_name),
+		Cur:              WriteStallCondition(cInfo.cur),
+		Prev:             WriteStallCondition(cInfo.prev),
+	})
+}
+
+//export gorocksdb_eventlistener_on_background_error
+func gorocksdb_eventlistener_on_background_error(idx int, reason C.int, cErr *C.char) {
+	getEventListener(idx).OnBackgroundError(BackgroundErrorReason(reason), newWriteError(C.GoString(cErr)))
+}
+
+//export gorocksdb_eventlistener_destruct
+func gorocksdb_eventlistener_destruct(idx int) {
+	eventListeners.unregister(idx)
+}
@@ -0,0 +1,109 @@
+package gorocksdb
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/ensure"
+)
+
+type recordingEventListener struct {
+	NoopEventListener
+	mu                 sync.Mutex
+	flushesBegun       []FlushJobInfo
+	flushesCompleted   []FlushJobInfo
+	compactionsBegun   []CompactionJobInfo
+	compactionsDone    []CompactionJobInfo
+	tableFilesCreated  []TableFileCreationInfo
+	compactionFinished chan struct{}
+}
+
+func (l *recordingEventListener) OnFlushBegin(info FlushJobInfo) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.flushesBegun = append(l.flushesBegun, info)
+}
+
+func (l *recordingEventListener) OnFlushCompleted(info FlushJobInfo) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.flushesCompleted = append(l.flushesCompleted, info)
+}
+
+func (l *recordingEventListener) OnCompactionBegin(info CompactionJobInfo) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.compactionsBegun = append(l.compactionsBegun, info)
+}
+
+func (l *recordingEventListener) OnCompactionCompleted(info CompactionJobInfo) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.compactionsDone = append(l.compactionsDone, info)
+	if len(l.compactionsDone) == 1 {
+		close(l.compactionFinished)
+	}
+}
+
+func (l *recordingEventListener) OnTableFileCreated(info TableFileCreationInfo) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tableFilesCreated = append(l.tableFilesCreated, info)
+}
+
+func TestEventListener(t *testing.T) {
+	listener := &recordingEventListener{compactionFinished: make(chan struct{})}
+	db := newTestDB(t, "TestEventListener", func(opts *Options) {
+		opts.SetDisableAutoCompactions(true)
+		opts.AddEventListener(listener)
+	})
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	for i := 0; i < 2; i++ {
+		ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val")))
+		ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("val")))
+		ensure.Nil(t, db.Flush(fo))
+	}
+	db.CompactRange(Range{nil, nil})
+
+	select {
+	case <-listener.compactionFinished:
+	case <-time.After(10 * time.Second):
+		t.Fatal("compaction was not reported")
+	}
+
+	listener.mu.Lock()
+	ensure.DeepEqual(t, len(listener.flushesBegun), 2)
+	ensure.DeepEqual(t, len(listener.flushesCompleted), 2)
+	flush := listener.flushesCompleted[0]
+	ensure.DeepEqual(t, flush.ColumnFamilyName, "default")
+	ensure.True(t, strings.HasSuffix(flush.FilePath, ".sst"))
+	ensure.True(t, flush.Reason != "")
+	ensure.DeepEqual(t, flush.NumEntries, uint64(2))
+	ensure.True(t, flush.FlushedBytes > 0)
+	ensure.True(t, flush.Elapsed > 0)
+	ensure.DeepEqual(t, listener.flushesBegun[0].FlushedBytes, uint64(0))
+
+	ensure.DeepEqual(t, len(listener.compactionsBegun), 1)
+	compaction := listener.compactionsDone[0]
+	ensure.Nil(t, compaction.Err)
+	ensure.DeepEqual(t, len(compaction.InputFiles), 2)
+	ensure.DeepEqual(t, len(compaction.OutputFiles), 1)
+	ensure.DeepEqual(t, compaction.NumInputRecords, uint64(4))
+	ensure.DeepEqual(t, compaction.NumOutputRecords, uint64(2))
+
+	ensure.DeepEqual(t, len(listener.tableFilesCreated), 3)
+	created := listener.tableFilesCreated[0]
+	ensure.Nil(t, created.Err)
+	ensure.DeepEqual(t, created.Reason, "Flush")
+	ensure.DeepEqual(t, created.NumEntries, uint64(2))
+	ensure.True(t, created.FileSize > 0)
+	listener.mu.Unlock()
+
+	db.Close()
+}
@@ -5,6 +5,11 @@ import "C"
 
 // FilterPolicy is a factory type that allows the RocksDB database to create a
 // filter, such as a bloom filter, which will used to reduce reads.
+//
+// RocksDB 7 removed support for filter policies implemented outside of
+// RocksDB, so only the policies returned by NewBloomFilter,
+// NewBloomFilterFull, NewRibbonFilter and NewNativeFilterPolicy can be set
+// with BlockBasedTableOptions.SetFilterPolicy.
 type FilterPolicy interface {
 	// keys contains a list of keys (potentially with duplicates)
 	// that are ordered according to the user supplied comparator.
@@ -45,40 +50,34 @@ func (fp nativeFilterPolicy) Name() string                               { retur
 // ignores trailing spaces, it would be incorrect to use a
 // FilterPolicy (like NewBloomFilterPolicy) that does not ignore
 // trailing spaces in keys.
+//
+// RocksDB 7 removed the block-based filter format, so this builds the same
+// full filter, one per table file, as NewBloomFilterFull with an integral
+// number of bits per key.
 func NewBloomFilter(bitsPerKey int) FilterPolicy {
-	return NewNativeFilterPolicy(C.rocksdb_filterpolicy_create_bloom(C.int(bitsPerKey)))
-}
-
-// Hold references to filter policies.
-var filterPolicies []FilterPolicy
-
-func registerFilterPolicy(fp FilterPolicy) int {
-	filterPolicies = append(filterPolicies, fp)
-	return len(filterPolicies) - 1
+	return NewNativeFilterPolicy(C.rocksdb_filterpolicy_create_bloom(C.double(bitsPerKey)))
 }
 
-//export gorocksdb_filterpolicy_create_filter
-func gorocksdb_filterpolicy_create_filter(idx int, cKeys **C.char, cKeysLen *C.size_t, cNumKeys C.int, cDstLen *C.size_t) *C.char {
-	rawKeys := charSlice(cKeys, cNumKeys)
-	keysLen := sizeSlice(cKeysLen, cNumKeys)
-	keys := make([][]byte, int(cNumKeys))
-	for i, len := range keysLen {
-		keys[i] = charToByte(rawKeys[i], len)
-	}
-
-	dst := filterPolicies[idx].CreateFilter(keys)
-	*cDstLen = C.size_t(len(dst))
-	return cByteSlice(dst)
-}
-
-//export gorocksdb_filterpolicy_key_may_match
-func gorocksdb_filterpolicy_key_may_match(idx int, cKey *C.char, cKeyLen C.size_t, cFilter *C.char, cFilterLen C.size_t) C.uchar {
-	key := charToByte(cKey, cKeyLen)
-	filter := charToByte(cFilter, cFilterLen)
-	return boolToChar(filterPolicies[idx].KeyMayMatch(key, filter))
+// NewBloomFilterFull returns a new filter policy that builds one bloom
+// filter per table file (or per partition with partitioned filters) with
+// approximately the specified number of bits per key. Fractional values
+// are allowed; 10 bits per key yield a false positive rate of ~1%.
+//
+// The same restrictions regarding custom comparators as for NewBloomFilter
+// apply.
+func NewBloomFilterFull(bitsPerKey float64) FilterPolicy {
+	return NewNativeFilterPolicy(C.rocksdb_filterpolicy_create_bloom_full(C.double(bitsPerKey)))
 }
 
-//export gorocksdb_filterpolicy_name
-func gorocksdb_filterpolicy_name(idx int) *C.char {
-	return stringToChar(filterPolicies[idx].Name())
+// NewRibbonFilter returns a new filter policy that uses a ribbon filter,
+// which saves ~30% of the memory of a bloom filter with the same false
+// positive rate but takes ~3-4x more CPU time to build. The bitsPerKey is
+// that of the bloom filter with the same false positive rate.
+//
+// Table files written to levels below bloomBeforeLevel use a bloom filter
+// instead, which avoids the extra build time for short-lived files. With
+// 0 flushes write bloom filters and compactions ribbon filters; with -1
+// ribbon filters are always used.
+func NewRibbonFilter(bitsPerKey float64, bloomBeforeLevel int) FilterPolicy {
+	return NewNativeFilterPolicy(C.rocksdb_filterpolicy_create_ribbon_hybrid(C.double(bitsPerKey), C.int(bloomBeforeLevel)))
 }
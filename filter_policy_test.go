@@ -1,6 +1,7 @@
 package gorocksdb
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/facebookgo/ensure"
@@ -17,49 +18,13 @@ func (f *fatalAsError) Fatal(a ...interface{}) {
 }
 
 func TestFilterPolicy(t *testing.T) {
-	var (
-		givenKeys          = [][]byte{[]byte("key1"), []byte("key2"), []byte("key3")}
-		givenFilter        = []byte("key")
-		createFilterCalled = false
-		keyMayMatchCalled  = false
-	)
-	policy := &mockFilterPolicy{
-		createFilter: func(keys [][]byte) []byte {
-			createFilterCalled = true
-			ensure.DeepEqual(&fatalAsError{t}, keys, givenKeys)
-			return givenFilter
-		},
-		keyMayMatch: func(key, filter []byte) bool {
-			keyMayMatchCalled = true
-			ensure.DeepEqual(&fatalAsError{t}, key, givenKeys[0])
-			ensure.DeepEqual(&fatalAsError{t}, filter, givenFilter)
-			return true
-		},
-	}
-
-	db := newTestDB(t, "TestFilterPolicy", func(opts *Options) {
-		blockOpts := NewDefaultBlockBasedTableOptions()
-		blockOpts.SetFilterPolicy(policy)
-		opts.SetBlockBasedTableFactory(blockOpts)
-	})
-	defer db.Close()
-
-	// insert keys
-	wo := NewDefaultWriteOptions()
-	for _, k := range givenKeys {
-		ensure.Nil(t, db.Put(wo, k, []byte("val")))
-	}
-
-	// flush to trigger the filter creation
-	ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
-	ensure.True(t, createFilterCalled)
-
-	// test key may match call
-	ro := NewDefaultReadOptions()
-	v1, err := db.Get(ro, givenKeys[0])
-	defer v1.Free()
-	ensure.Nil(t, err)
-	ensure.True(t, keyMayMatchCalled)
+	// RocksDB only supports its own filter policies
+	bbto := NewDefaultBlockBasedTableOptions()
+	defer bbto.Destroy()
+	defer func() {
+		ensure.NotNil(t, recover())
+	}()
+	bbto.SetFilterPolicy(&mockFilterPolicy{})
 }
 
 type mockFilterPolicy struct {
@@ -74,3 +39,67 @@ func (m *mockFilterPolicy) CreateFilter(keys [][]byte) []byte {
 func (m *mockFilterPolicy) KeyMayMatch(key, filter []byte) bool {
 	return m.keyMayMatch(key, filter)
 }
+
+func TestNativeFilterPolicies(t *testing.T) {
+	for name, policy := range map[string]FilterPolicy{
+		"Bloom":     NewBloomFilter(10),
+		"BloomFull": NewBloomFilterFull(10),
+		"Ribbon":    NewRibbonFilter(10, -1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			testFalsePositiveRate(t, policy)
+		})
+	}
+}
+
+// testFalsePositiveRate looks up absent keys in a table file with the
+// filter policy and checks that the filter rejects most of them.
+func testFalsePositiveRate(t *testing.T, policy FilterPolicy) {
+	const numKeys = 10000
+	var stats *Statistics
+	db := newTestDB(t, "TestNativeFilterPolicies", func(opts *Options) {
+		blockOpts := NewDefaultBlockBasedTableOptions()
+		blockOpts.SetFilterPolicy(policy)
+		opts.SetBlockBasedTableFactory(blockOpts)
+		opts.EnableStatistics()
+		stats = opts.GetStatistics()
+	})
+	defer db.Close()
+	defer stats.Destroy()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	for i := 0; i < numKeys; i++ {
+		ensure.Nil(t, db.Put(wo, []byte(fmt.Sprintf("key%d", i)), []byte("val")))
+	}
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	ensure.Nil(t, db.Flush(fo))
+
+	// all present keys must pass the filter
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	for i := 0; i < numKeys; i += 100 {
+		v, err := db.Get(ro, []byte(fmt.Sprintf("key%d", i)))
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, v.Data(), []byte("val"))
+		v.Free()
+	}
+	ensure.DeepEqual(t, stats.GetTickerCount(TickerBloomFilterFullTruePositive), uint64(numKeys/100))
+
+	ensure.Nil(t, stats.Reset())
+	for i := 0; i < numKeys; i++ {
+		v, err := db.Get(ro, []byte(fmt.Sprintf("absent%d", i)))
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, v.Size(), 0)
+		v.Free()
+	}
+	useful := stats.GetTickerCount(TickerBloomFilterUseful)
+	falsePositives := stats.GetTickerCount(TickerBloomFilterFullPositive)
+	ensure.DeepEqual(t, useful+falsePositives, uint64(numKeys))
+	// 10 bits per key yield a false positive rate of ~1%
+	rate := float64(falsePositives) / numKeys
+	if rate > 0.03 {
+		t.Fatalf("false positive rate too high: %f", rate)
+	}
+}
@@ -1,16 +1,12 @@
 #include "gorocksdb.h"
 #include "_cgo_export.h"
 
-/* Base */
-
-void gorocksdb_destruct_handler(void* state) { }
-
 /* Comparator */
 
 rocksdb_comparator_t* gorocksdb_comparator_create(uintptr_t idx) {
     return rocksdb_comparator_create(
         (void*)idx,
-        gorocksdb_destruct_handler,
+        (void (*)(void*))(gorocksdb_comparator_destruct),
         (int (*)(void*, const char*, size_t, const char*, size_t))(gorocksdb_comparator_compare),
         (const char *(*)(void*))(gorocksdb_comparator_name));
 }
@@ -20,31 +16,52 @@ rocksdb_comparator_t* gorocksdb_comparator_create(uintptr_t idx) {
 rocksdb_compactionfilter_t* gorocksdb_compactionfilter_create(uintptr_t idx) {
     return rocksdb_compactionfilter_create(
         (void*)idx,
-        gorocksdb_destruct_handler,
+        (void (*)(void*))(gorocksdb_compactionfilter_destruct),
         (unsigned char (*)(void*, int, const char*, size_t, const char*, size_t, char**, size_t*, unsigned char*))(gorocksdb_compactionfilter_filter),
         (const char *(*)(void*))(gorocksdb_compactionfilter_name));
 }
 
-/* Filter Policy */
-
-rocksdb_filterpolicy_t* gorocksdb_filterpolicy_create(uintptr_t idx) {
-    return rocksdb_filterpolicy_create(
+rocksdb_compactionfilterfactory_t* gorocksdb_compactionfilterfactory_create(uintptr_t idx) {
+    return rocksdb_compactionfilterfactory_create(
         (void*)idx,
-        gorocksdb_destruct_handler,
-        (char* (*)(void*, const char* const*, const size_t*, int, size_t*))(gorocksdb_filterpolicy_create_filter),
-        (unsigned char (*)(void*, const char*, size_t, const char*, size_t))(gorocksdb_filterpolicy_key_may_match),
-        gorocksdb_filterpolicy_delete_filter,
-        (const char *(*)(void*))(gorocksdb_filterpolicy_name));
+        (void (*)(void*))(gorocksdb_compactionfilterfactory_destruct),
+        (rocksdb_compactionfilter_t* (*)(void*, rocksdb_compactionfiltercontext_t*))(gorocksdb_compactionfilterfactory_create_filter),
+        (const char *(*)(void*))(gorocksdb_compactionfilterfactory_name));
 }
 
-void gorocksdb_filterpolicy_delete_filter(void* state, const char* v, size_t s) { }
+/* Event Listener */
+
+void gorocksdb_options_add_eventlistener(rocksdb_options_t* opts, uintptr_t idx) {
+    gorocksdb_eventlistener_callbacks_t callbacks = {
+        (void (*)(void*))(gorocksdb_eventlistener_destruct),
+        (void (*)(void*, const gorocksdb_flush_job_info_t*))(gorocksdb_eventlistener_on_flush_begin),
+        (void (*)(void*, const gorocksdb_flush_job_info_t*))(gorocksdb_eventlistener_on_flush_completed),
+        (void (*)(void*, const gorocksdb_compaction_job_info_t*))(gorocksdb_eventlistener_on_compaction_begin),
+        (void (*)(void*, const gorocksdb_compaction_job_info_t*))(gorocksdb_eventlistener_on_compaction_completed),
+        (void (*)(void*, const gorocksdb_table_file_creation_info_t*))(gorocksdb_eventlistener_on_table_file_created),
+        (void (*)(void*, const gorocksdb_table_file_deletion_info_t*))(gorocksdb_eventlistener_on_table_file_deleted),
+        (void (*)(void*, const gorocksdb_write_stall_info_t*))(gorocksdb_eventlistener_on_stall_conditions_changed),
+        (void (*)(void*, int, const char*))(gorocksdb_eventlistener_on_background_error),
+    };
+    gorocksdb_options_add_eventlistener_with_callbacks(opts, (void*)idx, &callbacks);
+}
+
+/* Logger */
+
+void gorocksdb_options_set_logger(rocksdb_options_t* opts, uintptr_t idx) {
+    gorocksdb_options_set_logger_with_callbacks(
+        opts,
+        (void*)idx,
+        (void (*)(void*))(gorocksdb_logger_destruct),
+        (void (*)(void*, int, const char*, size_t))(gorocksdb_logger_log));
+}
 
 /* Merge Operator */
 
 rocksdb_mergeoperator_t* gorocksdb_mergeoperator_create(uintptr_t idx) {
     return rocksdb_mergeoperator_create(
         (void*)idx,
-        gorocksdb_destruct_handler,
+        (void (*)(void*))(gorocksdb_mergeoperator_destruct),
         (char* (*)(void*, const char*, size_t, const char*, size_t, const char* const*, const size_t*, int, unsigned char*, size_t*))(gorocksdb_mergeoperator_full_merge),
         (char* (*)(void*, const char*, size_t, const char* const*, const size_t*, int, unsigned char*, size_t*))(gorocksdb_mergeoperator_partial_merge_multi),
         gorocksdb_mergeoperator_delete_value,
@@ -58,7 +75,7 @@ void gorocksdb_mergeoperator_delete_value(void* id, const char* v, size_t s) { }
 rocksdb_slicetransform_t* gorocksdb_slicetransform_create(uintptr_t idx) {
     return rocksdb_slicetransform_create(
     	(void*)idx,
-    	gorocksdb_destruct_handler,
+    	(void (*)(void*))(gorocksdb_slicetransform_destruct),
     	(char* (*)(void*, const char*, size_t, size_t*))(gorocksdb_slicetransform_transform),
     	(unsigned char (*)(void*, const char*, size_t))(gorocksdb_slicetransform_in_domain),
     	(unsigned char (*)(void*, const char*, size_t))(gorocksdb_slicetransform_in_range),
@@ -1,24 +1,176 @@
+#ifndef GOROCKSDB_H
+#define GOROCKSDB_H
+
 #include <stdlib.h>
 #include "rocksdb/c.h"
 
 // This API provides convenient C wrapper functions for rocksdb client.
 
-/* Base */
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+typedef struct gorocksdb_cancel_flag_t gorocksdb_cancel_flag_t;
+
+/* DB */
+
+extern void gorocksdb_set_db_options(rocksdb_t* db, int count, const char* const keys[], const char* const values[], char** errptr);
+extern unsigned char gorocksdb_property_aggregated_int(rocksdb_t* db, const char* propname, uint64_t* out_val);
+extern unsigned char gorocksdb_property_map_cf(rocksdb_t* db, rocksdb_column_family_handle_t* column_family, const char* propname, size_t* num_entries, char*** keys, char*** values);
+
+extern rocksdb_column_family_handle_t** gorocksdb_create_column_families(rocksdb_t* db, int num_column_families, const char* const* column_family_names, const rocksdb_options_t* const* column_family_options, char** errptr);
+extern void gorocksdb_drop_column_families(rocksdb_t* db, rocksdb_column_family_handle_t* const* column_families, int num_column_families, unsigned char* dropped, char** errptr);
+extern void gorocksdb_compact_range_opt(rocksdb_t* db, rocksdb_column_family_handle_t* column_family, rocksdb_compactoptions_t* opt, gorocksdb_cancel_flag_t* canceled, const char* start_key, size_t start_key_len, const char* limit_key, size_t limit_key_len, char** errptr);
+extern void gorocksdb_pause_background_work(rocksdb_t* db, char** errptr);
+extern void gorocksdb_continue_background_work(rocksdb_t* db, char** errptr);
+extern void gorocksdb_resume(rocksdb_t* db, char** errptr);
+extern void gorocksdb_sync_wal(rocksdb_t* db, char** errptr);
+extern void gorocksdb_delete_file(rocksdb_t* db, const char* name);
+
+/* Compact Range Options */
+
+extern gorocksdb_cancel_flag_t* gorocksdb_cancel_flag_create(void);
+extern void gorocksdb_cancel_flag_set(gorocksdb_cancel_flag_t* flag);
+extern void gorocksdb_cancel_flag_destroy(gorocksdb_cancel_flag_t* flag);
+
+/* Options */
+
+extern const char* gorocksdb_options_get_comparator_name(rocksdb_options_t* opts);
+extern char* gorocksdb_options_to_string(rocksdb_options_t* opts, char** errptr);
+extern void gorocksdb_options_set_info_log_level(rocksdb_options_t* opts, int level);
+extern void gorocksdb_options_set_logger(rocksdb_options_t* opts, uintptr_t idx);
+extern void gorocksdb_options_set_logger_with_callbacks(rocksdb_options_t* opts, void* state, void (*destructor)(void*), void (*log)(void*, int, const char*, size_t));
+extern void gorocksdb_load_latest_options(const char* db_path, rocksdb_options_t* db_options, size_t* num_column_families, char*** column_family_names, rocksdb_options_t*** column_family_options, char** errptr);
+
+/* Cache */
+
+extern rocksdb_cache_t* gorocksdb_cache_create_lru(size_t capacity, int num_shard_bits, unsigned char strict_capacity_limit, double high_pri_pool_ratio);
+
+/* Rate Limiter */
+
+extern void gorocksdb_ratelimiter_set_bytes_per_second(rocksdb_ratelimiter_t* limiter, int64_t bytes_per_second);
+extern int64_t gorocksdb_ratelimiter_get_bytes_per_second(rocksdb_ratelimiter_t* limiter);
+
+/* Statistics */
+
+typedef struct gorocksdb_statistics_t gorocksdb_statistics_t;
 
-extern void gorocksdb_destruct_handler(void* state);
+typedef struct gorocksdb_histogram_data_t {
+    double median;
+    double p95;
+    double p99;
+    double average;
+    double std_dev;
+    double max;
+    double min;
+    uint64_t count;
+    uint64_t sum;
+} gorocksdb_histogram_data_t;
+
+extern gorocksdb_statistics_t* gorocksdb_options_get_statistics(rocksdb_options_t* opts);
+extern void gorocksdb_statistics_destroy(gorocksdb_statistics_t* stats);
+extern uint64_t gorocksdb_statistics_get_ticker_count(gorocksdb_statistics_t* stats, uint32_t ticker);
+extern void gorocksdb_statistics_get_histogram_data(gorocksdb_statistics_t* stats, uint32_t histogram, gorocksdb_histogram_data_t* data);
+extern void gorocksdb_statistics_reset(gorocksdb_statistics_t* stats, char** errptr);
+extern char* gorocksdb_statistics_to_string(gorocksdb_statistics_t* stats);
+extern const char* gorocksdb_ticker_name_at(size_t i, uint32_t* ticker);
+extern const char* gorocksdb_histogram_name_at(size_t i, uint32_t* histogram);
+
+/* Block based table options */
+
+extern char* gorocksdb_block_based_options_to_string(rocksdb_block_based_table_options_t* opts, char** errptr);
+
+extern void gorocksdb_get_block_based_table_options_from_string(const rocksdb_block_based_table_options_t* base_options, const char* opts_str, rocksdb_block_based_table_options_t* new_options, char** errptr);
 
 /* CompactionFilter */
 
 extern rocksdb_compactionfilter_t* gorocksdb_compactionfilter_create(uintptr_t idx);
 
+extern rocksdb_compactionfilterfactory_t* gorocksdb_compactionfilterfactory_create(uintptr_t idx);
+
+extern uint32_t gorocksdb_compactionfiltercontext_column_family_id(rocksdb_compactionfiltercontext_t* context);
+
 /* Comparator */
 
 extern rocksdb_comparator_t* gorocksdb_comparator_create(uintptr_t idx);
 
-/* Filter Policy */
+/* Event Listener */
+
+typedef struct gorocksdb_flush_job_info_t {
+    uint32_t cf_id;
+    const char* cf_name;
+    const char* file_path;
+    int job_id;
+    uint64_t thread_id;
+    unsigned char triggered_writes_slowdown;
+    unsigned char triggered_writes_stop;
+    uint64_t smallest_seqno;
+    uint64_t largest_seqno;
+    const char* reason;
+    uint64_t num_entries;
+    uint64_t data_size;
+    uint64_t elapsed_micros;
+} gorocksdb_flush_job_info_t;
 
-extern rocksdb_filterpolicy_t* gorocksdb_filterpolicy_create(uintptr_t idx);
-extern void gorocksdb_filterpolicy_delete_filter(void* state, const char* v, size_t s);
+typedef struct gorocksdb_compaction_job_info_t {
+    uint32_t cf_id;
+    const char* cf_name;
+    const char* status;
+    int job_id;
+    uint64_t thread_id;
+    int base_input_level;
+    int output_level;
+    const char* const* input_files;
+    size_t num_input_files;
+    const char* const* output_files;
+    size_t num_output_files;
+    const char* reason;
+    uint64_t elapsed_micros;
+    uint64_t num_input_records;
+    uint64_t num_output_records;
+    uint64_t total_input_bytes;
+    uint64_t total_output_bytes;
+} gorocksdb_compaction_job_info_t;
+
+typedef struct gorocksdb_table_file_creation_info_t {
+    const char* db_name;
+    const char* cf_name;
+    const char* file_path;
+    int job_id;
+    const char* reason;
+    const char* status;
+    uint64_t file_size;
+    uint64_t num_entries;
+    uint64_t num_deletions;
+} gorocksdb_table_file_creation_info_t;
+
+typedef struct gorocksdb_table_file_deletion_info_t {
+    const char* db_name;
+    const char* file_path;
+    int job_id;
+    const char* status;
+} gorocksdb_table_file_deletion_info_t;
+
+typedef struct gorocksdb_write_stall_info_t {
+    const char* cf_name;
+    int cur;
+    int prev;
+} gorocksdb_write_stall_info_t;
+
+typedef struct gorocksdb_eventlistener_callbacks_t {
+    void (*destructor)(void*);
+    void (*on_flush_begin)(void*, const gorocksdb_flush_job_info_t*);
+    void (*on_flush_completed)(void*, const gorocksdb_flush_job_info_t*);
+    void (*on_compaction_begin)(void*, const gorocksdb_compaction_job_info_t*);
+    void (*on_compaction_completed)(void*, const gorocksdb_compaction_job_info_t*);
+    void (*on_table_file_created)(void*, const gorocksdb_table_file_creation_info_t*);
+    void (*on_table_file_deleted)(void*, const gorocksdb_table_file_deletion_info_t*);
+    void (*on_stall_conditions_changed)(void*, const gorocksdb_write_stall_info_t*);
+    void (*on_background_error)(void*, int, const char*);
+} gorocksdb_eventlistener_callbacks_t;
+
+extern void gorocksdb_options_add_eventlistener(rocksdb_options_t* opts, uintptr_t idx);
+extern void gorocksdb_options_add_eventlistener_with_callbacks(rocksdb_options_t* opts, void* state, const gorocksdb_eventlistener_callbacks_t* callbacks);
 
 /* Merge Operator */
 
@@ -28,3 +180,9 @@ extern void gorocksdb_mergeoperator_delete_value(void* state, const char* v, siz
 /* Slice Transform */
 
 extern rocksdb_slicetransform_t* gorocksdb_slicetransform_create(uintptr_t idx);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif  /* GOROCKSDB_H */
@@ -0,0 +1,631 @@
+// This file is built with and without the embed tag, so that none of the
+// functions declared in gorocksdb.h is left unresolved. The embedded RocksDB
+// of cockroachdb/c-rocksdb predates the APIs used here, so building with the
+// embed tag fails at compile time instead of at the first call.
+
+#include <stdarg.h>
+#include <stdio.h>
+#include <string.h>
+#include <atomic>
+#include <chrono>
+#include <map>
+#include <mutex>
+#include <set>
+#include <string>
+#include <vector>
+#include <unordered_map>
+
+#include "rocksdb/cache.h"
+#include "rocksdb/compaction_filter.h"
+#include "rocksdb/convenience.h"
+#include "rocksdb/db.h"
+#include "rocksdb/env.h"
+#include "rocksdb/listener.h"
+#include "rocksdb/rate_limiter.h"
+#include "rocksdb/statistics.h"
+#include "rocksdb/table.h"
+#include "rocksdb/utilities/options_util.h"
+#include "rocksdb/version.h"
+#include "gorocksdb.h"
+
+using rocksdb::BlockBasedTableOptions;
+using rocksdb::ColumnFamilyDescriptor;
+using rocksdb::ColumnFamilyHandle;
+using rocksdb::ConfigOptions;
+using rocksdb::DB;
+using rocksdb::DBOptions;
+using rocksdb::Options;
+using rocksdb::Statistics;
+using rocksdb::Status;
+
+// This file wraps the parts of the RocksDB C++ API which are not exposed by
+// rocksdb/c.h. The opaque C handles are unwrapped with the structs below,
+// which mirror the private definitions in RocksDB's c.cc. Only the rep
+// member, which c.cc always declares first, is accessed, and the handles
+// are allocated and freed by c.cc, except for the ones marked below, which
+// are allocated here and mirror all members of the c.cc struct. The layout
+// is still private to RocksDB, so the release it was checked against is
+// pinned here.
+
+static_assert(ROCKSDB_MAJOR == 10 && ROCKSDB_MINOR == 9,
+              "gorocksdb_ext.cc mirrors the c.cc structs of RocksDB 10.9, "
+              "check them against c.cc before changing the supported version");
+
+struct rocksdb_t { DB* rep; };
+// Allocated by gorocksdb_create_column_families.
+struct rocksdb_column_family_handle_t { ColumnFamilyHandle* rep; bool immortal; };
+struct rocksdb_options_t { Options rep; };
+struct rocksdb_block_based_table_options_t { BlockBasedTableOptions rep; };
+// Allocated by gorocksdb_cache_create_lru.
+struct rocksdb_cache_t { std::shared_ptr<rocksdb::Cache> rep; };
+struct rocksdb_compactoptions_t { rocksdb::CompactRangeOptions rep; };
+struct rocksdb_ratelimiter_t { std::shared_ptr<rocksdb::RateLimiter> rep; };
+struct rocksdb_compactionfiltercontext_t { rocksdb::CompactionFilter::Context rep; };
+
+struct gorocksdb_cancel_flag_t { std::atomic<bool> canceled; };
+struct gorocksdb_statistics_t { std::shared_ptr<Statistics> rep; };
+
+static bool SaveError(char** errptr, const Status& s) {
+    if (s.ok()) {
+        return false;
+    }
+    *errptr = strdup(s.ToString().c_str());
+    return true;
+}
+
+static std::unordered_map<std::string, std::string> ToOptionsMap(
+        int count, const char* const keys[], const char* const values[]) {
+    std::unordered_map<std::string, std::string> options;
+    for (int i = 0; i < count; i++) {
+        options[keys[i]] = values[i];
+    }
+    return options;
+}
+
+/* DB */
+
+void gorocksdb_set_db_options(rocksdb_t* db, int count, const char* const keys[], const char* const values[], char** errptr) {
+    SaveError(errptr, db->rep->SetDBOptions(ToOptionsMap(count, keys, values)));
+}
+
+unsigned char gorocksdb_property_aggregated_int(rocksdb_t* db, const char* propname, uint64_t* out_val) {
+    return db->rep->GetAggregatedIntProperty(propname, out_val);
+}
+
+unsigned char gorocksdb_property_map_cf(rocksdb_t* db, rocksdb_column_family_handle_t* column_family, const char* propname, size_t* num_entries, char*** keys, char*** values) {
+    std::map<std::string, std::string> props;
+    ColumnFamilyHandle* cf = column_family != nullptr ? column_family->rep : db->rep->DefaultColumnFamily();
+    if (!db->rep->GetMapProperty(cf, propname, &props)) {
+        return 0;
+    }
+    *num_entries = props.size();
+    *keys = static_cast<char**>(malloc(sizeof(char*) * props.size()));
+    *values = static_cast<char**>(malloc(sizeof(char*) * props.size()));
+    size_t i = 0;
+    for (const auto& prop : props) {
+        (*keys)[i] = strdup(prop.first.c_str());
+        (*values)[i] = strdup(prop.second.c_str());
+        i++;
+    }
+    return 1;
+}
+
+// ListColumnFamilies adds the names of the column families in the MANIFEST
+// of db to names.
+static Status ListColumnFamilies(DB* db, std::set<std::string>* names) {
+    std::vector<std::string> existing;
+    Status s = DB::ListColumnFamilies(db->GetDBOptions(), db->GetName(), &existing);
+    names->insert(existing.begin(), existing.end());
+    return s;
+}
+
+// CheckNewColumnFamilies returns an error if a name occurs twice in names or
+// names an existing column family, so that the batch fails before RocksDB
+// creates any of them.
+static Status CheckNewColumnFamilies(DB* db, int num_column_families, const char* const* column_family_names) {
+    std::set<std::string> names;
+    Status s = ListColumnFamilies(db, &names);
+    if (!s.ok()) {
+        return s;
+    }
+    for (int i = 0; i < num_column_families; i++) {
+        if (!names.insert(column_family_names[i]).second) {
+            return Status::InvalidArgument("Column family already exists: " + std::string(column_family_names[i]));
+        }
+    }
+    return Status::OK();
+}
+
+rocksdb_column_family_handle_t** gorocksdb_create_column_families(rocksdb_t* db, int num_column_families, const char* const* column_family_names, const rocksdb_options_t* const* column_family_options, char** errptr) {
+    if (SaveError(errptr, CheckNewColumnFamilies(db->rep, num_column_families, column_family_names))) {
+        return nullptr;
+    }
+    std::vector<ColumnFamilyDescriptor> descs;
+    for (int i = 0; i < num_column_families; i++) {
+        descs.emplace_back(column_family_names[i], rocksdb::ColumnFamilyOptions(column_family_options[i]->rep));
+    }
+    std::vector<ColumnFamilyHandle*> handles;
+    Status s = db->rep->CreateColumnFamilies(descs, &handles);
+    if (!s.ok()) {
+        // RocksDB keeps the column families created before the error, so
+        // they are dropped again.
+        std::string msg = s.ToString();
+        if (!handles.empty()) {
+            Status drop = db->rep->DropColumnFamilies(handles);
+            if (!drop.ok()) {
+                msg += "; column families not rolled back: " + drop.ToString();
+            }
+            for (auto handle : handles) {
+                db->rep->DestroyColumnFamilyHandle(handle);
+            }
+        }
+        *errptr = strdup(msg.c_str());
+        return nullptr;
+    }
+    rocksdb_column_family_handle_t** result = (rocksdb_column_family_handle_t**)malloc(handles.size() * sizeof(rocksdb_column_family_handle_t*));
+    for (size_t i = 0; i < handles.size(); i++) {
+        result[i] = new rocksdb_column_family_handle_t{handles[i], false};
+    }
+    return result;
+}
+
+// gorocksdb_drop_column_families sets dropped[i] if the column family of
+// column_families[i] was dropped, which on error may be true for some.
+void gorocksdb_drop_column_families(rocksdb_t* db, rocksdb_column_family_handle_t* const* column_families, int num_column_families, unsigned char* dropped, char** errptr) {
+    std::set<std::string> names;
+    if (SaveError(errptr, ListColumnFamilies(db->rep, &names))) {
+        return;
+    }
+    std::set<uint32_t> ids;
+    std::vector<ColumnFamilyHandle*> handles;
+    for (int i = 0; i < num_column_families; i++) {
+        ColumnFamilyHandle* handle = column_families[i]->rep;
+        if (handle->GetID() == 0) {
+            SaveError(errptr, Status::InvalidArgument("Can't drop default column family"));
+            return;
+        }
+        if (names.count(handle->GetName()) == 0) {
+            SaveError(errptr, Status::InvalidArgument("Column family already dropped: " + handle->GetName()));
+            return;
+        }
+        if (!ids.insert(handle->GetID()).second) {
+            SaveError(errptr, Status::InvalidArgument("Column family given twice: " + handle->GetName()));
+            return;
+        }
+        handles.push_back(handle);
+    }
+    if (!SaveError(errptr, db->rep->DropColumnFamilies(handles))) {
+        for (int i = 0; i < num_column_families; i++) {
+            dropped[i] = 1;
+        }
+        return;
+    }
+    // RocksDB drops the column families in order and stops at the first
+    // error, but doesn't say where, so the remaining ones are listed.
+    names.clear();
+    if (ListColumnFamilies(db->rep, &names).ok()) {
+        for (int i = 0; i < num_column_families; i++) {
+            dropped[i] = names.count(handles[i]->GetName()) == 0;
+        }
+    }
+}
+
+void gorocksdb_compact_range_opt(rocksdb_t* db, rocksdb_column_family_handle_t* column_family, rocksdb_compactoptions_t* opt, gorocksdb_cancel_flag_t* canceled, const char* start_key, size_t start_key_len, const char* limit_key, size_t limit_key_len, char** errptr) {
+    rocksdb::Slice start, limit;
+    if (start_key != nullptr) {
+        start = rocksdb::Slice(start_key, start_key_len);
+    }
+    if (limit_key != nullptr) {
+        limit = rocksdb::Slice(limit_key, limit_key_len);
+    }
+    ColumnFamilyHandle* cf = column_family != nullptr ? column_family->rep : db->rep->DefaultColumnFamily();
+    // The options may be shared by concurrent compactions, so the flag is
+    // set on a copy.
+    rocksdb::CompactRangeOptions options = opt->rep;
+    options.canceled = &canceled->canceled;
+    SaveError(errptr, db->rep->CompactRange(options, cf, start_key != nullptr ? &start : nullptr, limit_key != nullptr ? &limit : nullptr));
+}
+
+void gorocksdb_pause_background_work(rocksdb_t* db, char** errptr) {
+    SaveError(errptr, db->rep->PauseBackgroundWork());
+}
+
+void gorocksdb_continue_background_work(rocksdb_t* db, char** errptr) {
+    SaveError(errptr, db->rep->ContinueBackgroundWork());
+}
+
+void gorocksdb_resume(rocksdb_t* db, char** errptr) {
+    SaveError(errptr, db->rep->Resume());
+}
+
+void gorocksdb_sync_wal(rocksdb_t* db, char** errptr) {
+    SaveError(errptr, db->rep->SyncWAL());
+}
+
+void gorocksdb_delete_file(rocksdb_t* db, const char* name) {
+    db->rep->DeleteFile(name);
+}
+
+/* Compact Range Options */
+
+gorocksdb_cancel_flag_t* gorocksdb_cancel_flag_create(void) {
+    gorocksdb_cancel_flag_t* flag = new gorocksdb_cancel_flag_t;
+    flag->canceled.store(false);
+    return flag;
+}
+
+void gorocksdb_cancel_flag_set(gorocksdb_cancel_flag_t* flag) {
+    flag->canceled.store(true);
+}
+
+void gorocksdb_cancel_flag_destroy(gorocksdb_cancel_flag_t* flag) {
+    delete flag;
+}
+
+/* Options */
+
+void gorocksdb_options_set_info_log_level(rocksdb_options_t* opts, int level) {
+    opts->rep.info_log_level = static_cast<rocksdb::InfoLogLevel>(level);
+    // RocksDB only applies info_log_level to the loggers it creates itself.
+    if (opts->rep.info_log != nullptr) {
+        opts->rep.info_log->SetInfoLogLevel(opts->rep.info_log_level);
+    }
+}
+
+// GoLogger forwards the formatted log lines to the Go callback.
+class GoLogger : public rocksdb::Logger {
+ public:
+    GoLogger(rocksdb::InfoLogLevel level, void* state, void (*destructor)(void*), void (*log)(void*, int, const char*, size_t))
+        : rocksdb::Logger(level), state_(state), destructor_(destructor), log_(log) {}
+
+    ~GoLogger() override {
+        destructor_(state_);
+    }
+
+    void Logv(const char* format, va_list ap) override {
+        Logv(rocksdb::InfoLogLevel::INFO_LEVEL, format, ap);
+    }
+
+    void Logv(const rocksdb::InfoLogLevel level, const char* format, va_list ap) override {
+        if (level < GetInfoLogLevel()) {
+            return;
+        }
+        char buf[512];
+        va_list backup_ap;
+        va_copy(backup_ap, ap);
+        int n = vsnprintf(buf, sizeof(buf), format, ap);
+        if (n < 0) {
+            va_end(backup_ap);
+            return;
+        }
+        if (static_cast<size_t>(n) < sizeof(buf)) {
+            log_(state_, static_cast<int>(level), buf, n);
+        } else {
+            std::string msg(n, '\0');
+            vsnprintf(&msg[0], n + 1, format, backup_ap);
+            log_(state_, static_cast<int>(level), msg.data(), n);
+        }
+        va_end(backup_ap);
+    }
+
+ private:
+    void* state_;
+    void (*destructor_)(void*);
+    void (*log_)(void*, int, const char*, size_t);
+};
+
+void gorocksdb_options_set_logger_with_callbacks(rocksdb_options_t* opts, void* state, void (*destructor)(void*), void (*log)(void*, int, const char*, size_t)) {
+    opts->rep.info_log = std::make_shared<GoLogger>(opts->rep.info_log_level, state, destructor, log);
+}
+
+void gorocksdb_load_latest_options(const char* db_path, rocksdb_options_t* db_options, size_t* num_column_families, char*** column_family_names, rocksdb_options_t*** column_family_options, char** errptr) {
+    DBOptions db_opts;
+    std::vector<ColumnFamilyDescriptor> cf_descs;
+    ConfigOptions config_options;
+    if (SaveError(errptr, rocksdb::LoadLatestOptions(config_options, db_path, &db_opts, &cf_descs))) {
+        return;
+    }
+    db_options->rep = Options(db_opts, rocksdb::ColumnFamilyOptions());
+
+    size_t n = cf_descs.size();
+    char** names = (char**)malloc(n * sizeof(char*));
+    rocksdb_options_t** opts = (rocksdb_options_t**)malloc(n * sizeof(rocksdb_options_t*));
+    for (size_t i = 0; i < n; i++) {
+        names[i] = strdup(cf_descs[i].name.c_str());
+        opts[i] = rocksdb_options_create();
+        opts[i]->rep = Options(db_opts, cf_descs[i].options);
+    }
+    *num_column_families = n;
+    *column_family_names = names;
+    *column_family_options = opts;
+}
+
+const char* gorocksdb_options_get_comparator_name(rocksdb_options_t* opts) {
+    return opts->rep.comparator->Name();
+}
+
+char* gorocksdb_options_to_string(rocksdb_options_t* opts, char** errptr) {
+    ConfigOptions config_options;
+    std::string db_str, cf_str;
+    if (SaveError(errptr, rocksdb::GetStringFromDBOptions(config_options, opts->rep, &db_str)) ||
+        SaveError(errptr, rocksdb::GetStringFromColumnFamilyOptions(config_options, opts->rep, &cf_str))) {
+        return nullptr;
+    }
+    return strdup((db_str + ";" + cf_str).c_str());
+}
+
+/* Cache */
+
+rocksdb_cache_t* gorocksdb_cache_create_lru(size_t capacity, int num_shard_bits, unsigned char strict_capacity_limit, double high_pri_pool_ratio) {
+    rocksdb::LRUCacheOptions cache_opts;
+    cache_opts.capacity = capacity;
+    cache_opts.num_shard_bits = num_shard_bits;
+    cache_opts.strict_capacity_limit = strict_capacity_limit;
+    cache_opts.high_pri_pool_ratio = high_pri_pool_ratio;
+    return new rocksdb_cache_t{rocksdb::NewLRUCache(cache_opts)};
+}
+
+/* Rate Limiter */
+
+void gorocksdb_ratelimiter_set_bytes_per_second(rocksdb_ratelimiter_t* limiter, int64_t bytes_per_second) {
+    limiter->rep->SetBytesPerSecond(bytes_per_second);
+}
+
+int64_t gorocksdb_ratelimiter_get_bytes_per_second(rocksdb_ratelimiter_t* limiter) {
+    return limiter->rep->GetBytesPerSecond();
+}
+
+/* Statistics */
+
+gorocksdb_statistics_t* gorocksdb_options_get_statistics(rocksdb_options_t* opts) {
+    if (opts->rep.statistics == nullptr) {
+        return nullptr;
+    }
+    return new gorocksdb_statistics_t{opts->rep.statistics};
+}
+
+void gorocksdb_statistics_destroy(gorocksdb_statistics_t* stats) {
+    delete stats;
+}
+
+uint64_t gorocksdb_statistics_get_ticker_count(gorocksdb_statistics_t* stats, uint32_t ticker) {
+    return stats->rep->getTickerCount(ticker);
+}
+
+void gorocksdb_statistics_get_histogram_data(gorocksdb_statistics_t* stats, uint32_t histogram, gorocksdb_histogram_data_t* data) {
+    rocksdb::HistogramData hist;
+    stats->rep->histogramData(histogram, &hist);
+    data->median = hist.median;
+    data->p95 = hist.percentile95;
+    data->p99 = hist.percentile99;
+    data->average = hist.average;
+    data->std_dev = hist.standard_deviation;
+    data->max = hist.max;
+    data->min = hist.min;
+    data->count = hist.count;
+    data->sum = hist.sum;
+}
+
+void gorocksdb_statistics_reset(gorocksdb_statistics_t* stats, char** errptr) {
+    SaveError(errptr, stats->rep->Reset());
+}
+
+char* gorocksdb_statistics_to_string(gorocksdb_statistics_t* stats) {
+    return strdup(stats->rep->ToString().c_str());
+}
+
+// The name maps list the tickers and histograms of the linked RocksDB
+// version with their enum values. Go reads them once and resolves the names
+// itself.
+
+const char* gorocksdb_ticker_name_at(size_t i, uint32_t* ticker) {
+    if (i >= rocksdb::TickersNameMap.size()) {
+        return nullptr;
+    }
+    *ticker = rocksdb::TickersNameMap[i].first;
+    return rocksdb::TickersNameMap[i].second.c_str();
+}
+
+const char* gorocksdb_histogram_name_at(size_t i, uint32_t* histogram) {
+    if (i >= rocksdb::HistogramsNameMap.size()) {
+        return nullptr;
+    }
+    *histogram = rocksdb::HistogramsNameMap[i].first;
+    return rocksdb::HistogramsNameMap[i].second.c_str();
+}
+
+/* Event Listener */
+
+// GoEventListener forwards the events to the Go callbacks. The strings in
+// the info structs are only valid for the duration of a callback.
+class GoEventListener : public rocksdb::EventListener {
+ public:
+    GoEventListener(void* state, const gorocksdb_eventlistener_callbacks_t* callbacks)
+        : state_(state), callbacks_(*callbacks) {}
+
+    ~GoEventListener() override {
+        callbacks_.destructor(state_);
+    }
+
+    const char* Name() const override { return "GoEventListener"; }
+
+    void OnFlushBegin(DB* db, const rocksdb::FlushJobInfo& info) override {
+        {
+            std::lock_guard<std::mutex> lock(flush_mu_);
+            flush_starts_[std::make_pair(db, info.job_id)] = std::chrono::steady_clock::now();
+        }
+        gorocksdb_flush_job_info_t c = ToC(info);
+        callbacks_.on_flush_begin(state_, &c);
+    }
+
+    void OnFlushCompleted(DB* db, const rocksdb::FlushJobInfo& info) override {
+        gorocksdb_flush_job_info_t c = ToC(info);
+        c.num_entries = info.table_properties.num_entries;
+        c.data_size = info.table_properties.data_size;
+        {
+            // RocksDB has no duration in the flush info, so it is measured
+            // from the begin event of the same job.
+            std::lock_guard<std::mutex> lock(flush_mu_);
+            auto it = flush_starts_.find(std::make_pair(db, info.job_id));
+            if (it != flush_starts_.end()) {
+                c.elapsed_micros = std::chrono::duration_cast<std::chrono::microseconds>(
+                    std::chrono::steady_clock::now() - it->second).count();
+                flush_starts_.erase(it);
+            }
+        }
+        callbacks_.on_flush_completed(state_, &c);
+    }
+
+    void OnCompactionBegin(DB*, const rocksdb::CompactionJobInfo& info) override {
+        OnCompaction(info, callbacks_.on_compaction_begin);
+    }
+
+    void OnCompactionCompleted(DB*, const rocksdb::CompactionJobInfo& info) override {
+        OnCompaction(info, callbacks_.on_compaction_completed);
+    }
+
+    void OnTableFileCreated(const rocksdb::TableFileCreationInfo& info) override {
+        std::string status = info.status.ToString();
+        gorocksdb_table_file_creation_info_t c;
+        c.db_name = info.db_name.c_str();
+        c.cf_name = info.cf_name.c_str();
+        c.file_path = info.file_path.c_str();
+        c.job_id = info.job_id;
+        c.reason = TableFileCreationReasonString(info.reason);
+        c.status = info.status.ok() ? nullptr : status.c_str();
+        c.file_size = info.file_size;
+        c.num_entries = info.table_properties.num_entries;
+        c.num_deletions = info.table_properties.num_deletions;
+        callbacks_.on_table_file_created(state_, &c);
+    }
+
+    void OnTableFileDeleted(const rocksdb::TableFileDeletionInfo& info) override {
+        std::string status = info.status.ToString();
+        gorocksdb_table_file_deletion_info_t c;
+        c.db_name = info.db_name.c_str();
+        c.file_path = info.file_path.c_str();
+        c.job_id = info.job_id;
+        c.status = info.status.ok() ? nullptr : status.c_str();
+        callbacks_.on_table_file_deleted(state_, &c);
+    }
+
+    void OnStallConditionsChanged(const rocksdb::WriteStallInfo& info) override {
+        gorocksdb_write_stall_info_t c;
+        c.cf_name = info.cf_name.c_str();
+        c.cur = WriteStallConditionToC(info.condition.cur);
+        c.prev = WriteStallConditionToC(info.condition.prev);
+        callbacks_.on_stall_conditions_changed(state_, &c);
+    }
+
+    void OnBackgroundError(rocksdb::BackgroundErrorReason reason, Status* bg_error) override {
+        std::string status = bg_error->ToString();
+        callbacks_.on_background_error(state_, static_cast<int>(reason), status.c_str());
+    }
+
+ private:
+    static gorocksdb_flush_job_info_t ToC(const rocksdb::FlushJobInfo& info) {
+        gorocksdb_flush_job_info_t c;
+        c.cf_id = info.cf_id;
+        c.cf_name = info.cf_name.c_str();
+        c.file_path = info.file_path.c_str();
+        c.job_id = info.job_id;
+        c.thread_id = info.thread_id;
+        c.triggered_writes_slowdown = info.triggered_writes_slowdown;
+        c.triggered_writes_stop = info.triggered_writes_stop;
+        c.smallest_seqno = info.smallest_seqno;
+        c.largest_seqno = info.largest_seqno;
+        c.reason = rocksdb::GetFlushReasonString(info.flush_reason);
+        c.num_entries = 0;
+        c.data_size = 0;
+        c.elapsed_micros = 0;
+        return c;
+    }
+
+    void OnCompaction(const rocksdb::CompactionJobInfo& info, void (*cb)(void*, const gorocksdb_compaction_job_info_t*)) {
+        std::string status = info.status.ToString();
+        std::vector<const char*> input_files, output_files;
+        for (const auto& f : info.input_files) {
+            input_files.push_back(f.c_str());
+        }
+        for (const auto& f : info.output_files) {
+            output_files.push_back(f.c_str());
+        }
+        gorocksdb_compaction_job_info_t c;
+        c.cf_id = info.cf_id;
+        c.cf_name = info.cf_name.c_str();
+        c.status = info.status.ok() ? nullptr : status.c_str();
+        c.job_id = info.job_id;
+        c.thread_id = info.thread_id;
+        c.base_input_level = info.base_input_level;
+        c.output_level = info.output_level;
+        c.input_files = input_files.data();
+        c.num_input_files = input_files.size();
+        c.output_files = output_files.data();
+        c.num_output_files = output_files.size();
+        c.reason = rocksdb::GetCompactionReasonString(info.compaction_reason);
+        c.elapsed_micros = info.stats.elapsed_micros;
+        c.num_input_records = info.stats.num_input_records;
+        c.num_output_records = info.stats.num_output_records;
+        c.total_input_bytes = info.stats.total_input_bytes;
+        c.total_output_bytes = info.stats.total_output_bytes;
+        cb(state_, &c);
+    }
+
+    static const char* TableFileCreationReasonString(rocksdb::TableFileCreationReason reason) {
+        switch (reason) {
+        case rocksdb::TableFileCreationReason::kFlush:
+            return "Flush";
+        case rocksdb::TableFileCreationReason::kCompaction:
+            return "Compaction";
+        case rocksdb::TableFileCreationReason::kRecovery:
+            return "Recovery";
+        default:
+            return "Misc";
+        }
+    }
+
+    // WriteStallConditionToC maps the condition to the WriteStallCondition
+    // values of the Go package.
+    static int WriteStallConditionToC(rocksdb::WriteStallCondition condition) {
+        switch (condition) {
+        case rocksdb::WriteStallCondition::kDelayed:
+            return 1;
+        case rocksdb::WriteStallCondition::kStopped:
+            return 2;
+        default:
+            return 0;
+        }
+    }
+
+    void* state_;
+    gorocksdb_eventlistener_callbacks_t callbacks_;
+    std::mutex flush_mu_;
+    std::map<std::pair<DB*, int>, std::chrono::steady_clock::time_point> flush_starts_;
+};
+
+void gorocksdb_options_add_eventlistener_with_callbacks(rocksdb_options_t* opts, void* state, const gorocksdb_eventlistener_callbacks_t* callbacks) {
+    opts->rep.listeners.emplace_back(std::make_shared<GoEventListener>(state, callbacks));
+}
+
+/* Block based table options */
+
+char* gorocksdb_block_based_options_to_string(rocksdb_block_based_table_options_t* opts, char** errptr) {
+    ConfigOptions config_options;
+    std::unique_ptr<rocksdb::TableFactory> factory(rocksdb::NewBlockBasedTableFactory(opts->rep));
+    std::string str;
+    if (SaveError(errptr, factory->GetOptionString(config_options, &str))) {
+        return nullptr;
+    }
+    return strdup(str.c_str());
+}
+
+void gorocksdb_get_block_based_table_options_from_string(const rocksdb_block_based_table_options_t* base_options, const char* opts_str, rocksdb_block_based_table_options_t* new_options, char** errptr) {
+    ConfigOptions config_options;
+    SaveError(errptr, rocksdb::GetBlockBasedTableOptionsFromString(config_options, base_options->rep, opts_str, &new_options->rep));
+}
+
+/* CompactionFilter */
+
+uint32_t gorocksdb_compactionfiltercontext_column_family_id(rocksdb_compactionfiltercontext_t* context) {
+    return context->rep.column_family_id;
+}
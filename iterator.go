@@ -28,11 +28,14 @@ import (
 //
 type Iterator struct {
 	c *C.rocksdb_iterator_t
+
+	// Releases the reference the iterator holds on its DB.
+	release func()
 }
 
 // NewNativeIterator creates a Iterator object.
 func NewNativeIterator(c unsafe.Pointer) *Iterator {
-	return &Iterator{(*C.rocksdb_iterator_t)(c)}
+	return &Iterator{c: (*C.rocksdb_iterator_t)(c)}
 }
 
 // Valid returns false only when an Iterator has iterated past either the
@@ -109,4 +112,8 @@ func (iter *Iterator) Err() error {
 func (iter *Iterator) Close() {
 	C.rocksdb_iter_destroy(iter.c)
 	iter.c = nil
+	if iter.release != nil {
+		iter.release()
+		iter.release = nil
+	}
 }
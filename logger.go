@@ -0,0 +1,53 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+
+// A Logger receives the messages RocksDB writes to its info log. Messages
+// below the level set with Options.SetInfoLogLevel are not passed to the
+// logger. Log may be called concurrently from RocksDB's background threads.
+type Logger interface {
+	Log(level InfoLogLevel, msg string)
+}
+
+// LoggerFunc is an adapter to use an ordinary function as a Logger.
+type LoggerFunc func(level InfoLogLevel, msg string)
+
+// Log calls f(level, msg).
+func (f LoggerFunc) Log(level InfoLogLevel, msg string) {
+	f(level, msg)
+}
+
+// SetLogger sets the logger which receives the info log of all databases
+// opened with these options instead of the LOG file in the database
+// directory or in the directory set by SetDbLogDir.
+func (opts *Options) SetLogger(logger Logger) {
+	idx := registerLogger(logger)
+	C.gorocksdb_options_set_logger(opts.c, C.uintptr_t(idx))
+}
+
+// Hold references to loggers.
+var loggers = newRegistry()
+
+func registerLogger(logger Logger) int {
+	return loggers.register(logger)
+}
+
+func getLogger(idx int) Logger {
+	return loggers.lookup(idx).(Logger)
+}
+
+//export gorocksdb_logger_log
+func gorocksdb_logger_log(idx int, level C.int, cMsg *C.char, cMsgLen C.size_t) {
+	msg := C.GoStringN(cMsg, C.int(cMsgLen))
+	for len(msg) > 0 && msg[len(msg)-1] == '\n' {
+		msg = msg[:len(msg)-1]
+	}
+	getLogger(idx).Log(InfoLogLevel(level), msg)
+}
+
+//export gorocksdb_logger_destruct
+func gorocksdb_logger_destruct(idx int) {
+	loggers.unregister(idx)
+}
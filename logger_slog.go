@@ -0,0 +1,33 @@
+//go:build go1.21
+
+package gorocksdb
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlogLogger returns a Logger which writes the info log to l. The
+// RocksDB log level is mapped with SlogLevel.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return LoggerFunc(func(level InfoLogLevel, msg string) {
+		l.Log(context.Background(), SlogLevel(level), msg)
+	})
+}
+
+// SlogLevel maps a RocksDB log level to a slog level. Header messages are
+// logged at info level and fatal messages above error level.
+func SlogLevel(level InfoLogLevel) slog.Level {
+	switch level {
+	case DebugInfoLogLevel:
+		return slog.LevelDebug
+	case WarnInfoLogLevel:
+		return slog.LevelWarn
+	case ErrorInfoLogLevel:
+		return slog.LevelError
+	case FatalInfoLogLevel:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,21 @@
+//go:build go1.21
+
+package gorocksdb
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	logger.Log(WarnInfoLogLevel, "stall")
+	logger.Log(HeaderInfoLogLevel, "header")
+	ensure.StringContains(t, buf.String(), "level=WARN msg=stall")
+	ensure.StringContains(t, buf.String(), "level=INFO msg=header")
+	ensure.DeepEqual(t, SlogLevel(FatalInfoLogLevel), slog.LevelError+4)
+}
@@ -0,0 +1,53 @@
+package gorocksdb
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+type recordingLogger struct {
+	mu     sync.Mutex
+	levels map[InfoLogLevel]int
+	msgs   []string
+}
+
+func (l *recordingLogger) Log(level InfoLogLevel, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levels[level]++
+	l.msgs = append(l.msgs, msg)
+}
+
+func TestLogger(t *testing.T) {
+	logger := &recordingLogger{levels: make(map[InfoLogLevel]int)}
+	db := newTestDB(t, "TestLogger", func(opts *Options) {
+		// debug builds of RocksDB default to the debug level
+		opts.SetInfoLogLevel(InfoInfoLogLevel)
+		opts.SetLogger(logger)
+	})
+	db.Close()
+
+	logger.mu.Lock()
+	ensure.True(t, logger.levels[InfoInfoLogLevel] > 0)
+	ensure.DeepEqual(t, logger.levels[DebugInfoLogLevel], 0)
+	for _, msg := range logger.msgs {
+		ensure.False(t, strings.HasSuffix(msg, "\n"))
+	}
+	logger.mu.Unlock()
+
+	// the level set after the logger applies to it as well
+	logger = &recordingLogger{levels: make(map[InfoLogLevel]int)}
+	db = newTestDB(t, "TestLoggerLevel", func(opts *Options) {
+		opts.SetLogger(logger)
+		opts.SetInfoLogLevel(WarnInfoLogLevel)
+	})
+	db.Close()
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	ensure.DeepEqual(t, logger.levels[DebugInfoLogLevel], 0)
+	ensure.DeepEqual(t, logger.levels[InfoInfoLogLevel], 0)
+}
@@ -65,11 +65,14 @@ func (mo nativeMergeOperator) PartialMerge(key, leftOperand, rightOperand []byte
 func (mo nativeMergeOperator) Name() string { return "" }
 
 // Hold references to merge operators.
-var mergeOperators []MergeOperator
+var mergeOperators = newRegistry()
 
 func registerMergeOperator(merger MergeOperator) int {
-	mergeOperators = append(mergeOperators, merger)
-	return len(mergeOperators) - 1
+	return mergeOperators.register(merger)
+}
+
+func getMergeOperator(idx int) MergeOperator {
+	return mergeOperators.lookup(idx).(MergeOperator)
 }
 
 //export gorocksdb_mergeoperator_full_merge
@@ -83,7 +86,7 @@ func gorocksdb_mergeoperator_full_merge(idx int, cKey *C.char, cKeyLen C.size_t,
 		operands[i] = charToByte(rawOperands[i], len)
 	}
 
-	newValue, success := mergeOperators[idx].FullMerge(key, existingValue, operands)
+	newValue, success := getMergeOperator(idx).FullMerge(key, existingValue, operands)
 	newValueLen := len(newValue)
 
 	*cNewValueLen = C.size_t(newValueLen)
@@ -105,7 +108,7 @@ func gorocksdb_mergeoperator_partial_merge_multi(idx int, cKey *C.char, cKeyLen
 	var newValue []byte
 	success := true
 
-	merger := mergeOperators[idx]
+	merger := getMergeOperator(idx)
 	leftOperand := operands[0]
 	for i := 1; i < int(cNumOperands); i++ {
 		newValue, success = merger.PartialMerge(key, leftOperand, operands[i])
@@ -124,5 +127,10 @@ func gorocksdb_mergeoperator_partial_merge_multi(idx int, cKey *C.char, cKeyLen
 
 //export gorocksdb_mergeoperator_name
 func gorocksdb_mergeoperator_name(idx int) *C.char {
-	return stringToChar(mergeOperators[idx].Name())
+	return stringToChar(getMergeOperator(idx).Name())
+}
+
+//export gorocksdb_mergeoperator_destruct
+func gorocksdb_mergeoperator_destruct(idx int) {
+	mergeOperators.unregister(idx)
 }
@@ -0,0 +1,270 @@
+// Package metrics exports RocksDB properties and statistics in the
+// Prometheus text exposition format.
+//
+// A Collector samples the database periodically and serves the latest
+// sample over HTTP:
+//
+//	c := metrics.NewCollector(db, metrics.Config{
+//		Statistics: opts.GetStatistics(),
+//		BlockCache: cache,
+//	})
+//	defer c.Close()
+//	http.Handle("/metrics", c)
+//
+// The collector must be closed before the database, the statistics or the
+// column family handles it reads from are released.
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tecbot/gorocksdb"
+)
+
+// DefaultProperties are the integer properties sampled when
+// Config.Properties is nil.
+var DefaultProperties = []string{
+	gorocksdb.PropertyEstimateNumKeys,
+	gorocksdb.PropertyEstimateLiveDataSize,
+	gorocksdb.PropertyEstimatePendingCompactionBytes,
+	gorocksdb.PropertyEstimateTableReadersMem,
+	gorocksdb.PropertyNumRunningCompactions,
+	gorocksdb.PropertyNumRunningFlushes,
+	gorocksdb.PropertyNumImmutableMemTable,
+	gorocksdb.PropertyNumEntriesActiveMemTable,
+	gorocksdb.PropertyNumDeletesActiveMemTable,
+	gorocksdb.PropertyNumSnapshots,
+	gorocksdb.PropertyNumLiveVersions,
+	gorocksdb.PropertyCurSizeAllMemTables,
+	gorocksdb.PropertySizeAllMemTables,
+	gorocksdb.PropertyTotalSstFilesSize,
+	gorocksdb.PropertyLiveSstFilesSize,
+	gorocksdb.PropertyMemTableFlushPending,
+	gorocksdb.PropertyCompactionPending,
+	gorocksdb.PropertyBackgroundErrors,
+	gorocksdb.PropertyIsWriteStopped,
+	gorocksdb.PropertyActualDelayedWriteRate,
+}
+
+// DefaultInterval is the sampling interval used when Config.Interval is zero.
+const DefaultInterval = 10 * time.Second
+
+// Config configures what a Collector samples.
+type Config struct {
+	// Namespace is the prefix of all metric names. Default: "rocksdb"
+	Namespace string
+
+	// Interval is the time between two samples. A negative interval
+	// disables periodic sampling; Collect has to be called explicitly then.
+	// Default: DefaultInterval
+	Interval time.Duration
+
+	// Properties are the integer properties sampled for every column
+	// family. Properties which are unknown or not integers are skipped.
+	// Default: DefaultProperties
+	Properties []string
+
+	// ColumnFamilies maps the names used in the "cf" label to the column
+	// families whose properties are sampled. If nil only the default column
+	// family is sampled.
+	ColumnFamilies map[string]*gorocksdb.ColumnFamilyHandle
+
+	// Statistics, if set, exports the tickers as counters and the
+	// histograms as summaries.
+	Statistics *gorocksdb.Statistics
+
+	// BlockCache, if set, exports the memory used by the block cache.
+	BlockCache *gorocksdb.Cache
+}
+
+// Collector samples a database and serves the latest sample in the
+// Prometheus text exposition format. It implements http.Handler.
+type Collector struct {
+	db  *gorocksdb.DB
+	cfg Config
+
+	mu     sync.RWMutex
+	sample []byte
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewCollector creates a collector for the database, takes the first sample
+// and starts sampling in the background.
+func NewCollector(db *gorocksdb.DB, cfg Config) *Collector {
+	if cfg.Namespace == "" {
+		cfg.Namespace = "rocksdb"
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Properties == nil {
+		cfg.Properties = DefaultProperties
+	}
+	c := &Collector{
+		db:   db,
+		cfg:  cfg,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	c.Collect()
+	if cfg.Interval > 0 {
+		go c.run()
+	} else {
+		close(c.done)
+	}
+	return c
+}
+
+func (c *Collector) run() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.Collect()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Collect takes a new sample.
+func (c *Collector) Collect() {
+	w := &writer{namespace: c.cfg.Namespace}
+	c.collectProperties(w)
+	if c.cfg.Statistics != nil {
+		c.collectStatistics(w)
+	}
+	if c.cfg.BlockCache != nil {
+		w.family("block_cache_usage_bytes", "gauge", "Memory used by the block cache.")
+		w.value("", nil, float64(c.cfg.BlockCache.GetUsage()))
+	}
+
+	c.mu.Lock()
+	c.sample = w.buf.Bytes()
+	c.mu.Unlock()
+}
+
+func (c *Collector) collectProperties(w *writer) {
+	names := make([]string, 0, len(c.cfg.ColumnFamilies))
+	for name := range c.cfg.ColumnFamilies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, prop := range c.cfg.Properties {
+		var labels [][2]string
+		var values []uint64
+		if c.cfg.ColumnFamilies == nil {
+			if v, ok := c.db.GetIntProperty(prop); ok {
+				labels = append(labels, [2]string{"cf", "default"})
+				values = append(values, v)
+			}
+		}
+		for _, name := range names {
+			if v, ok := c.db.GetIntPropertyCF(prop, c.cfg.ColumnFamilies[name]); ok {
+				labels = append(labels, [2]string{"cf", name})
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		w.family(metricName(prop), "gauge", "RocksDB property "+prop+".")
+		for i, v := range values {
+			w.value("", labels[i:i+1], float64(v))
+		}
+	}
+}
+
+func (c *Collector) collectStatistics(w *writer) {
+	stats := c.cfg.Statistics
+	for _, t := range gorocksdb.SupportedTickers() {
+		name := t.String()
+		w.family(metricName(name)+"_total", "counter", "RocksDB ticker "+name+".")
+		w.value("", nil, float64(stats.GetTickerCount(t)))
+	}
+	for _, h := range gorocksdb.SupportedHistograms() {
+		name := h.String()
+		data := stats.GetHistogramData(h)
+		w.family(metricName(name), "summary", "RocksDB histogram "+name+".")
+		w.value("", [][2]string{{"quantile", "0.5"}}, data.Median)
+		w.value("", [][2]string{{"quantile", "0.95"}}, data.P95)
+		w.value("", [][2]string{{"quantile", "0.99"}}, data.P99)
+		w.value("", [][2]string{{"quantile", "1"}}, data.Max)
+		w.value("_sum", nil, float64(data.Sum))
+		w.value("_count", nil, float64(data.Count))
+	}
+}
+
+// ServeHTTP writes the latest sample.
+func (c *Collector) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	c.mu.RLock()
+	sample := c.sample
+	c.mu.RUnlock()
+
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	rw.Write(sample)
+}
+
+// Close stops the background sampling. The latest sample is still served
+// after the collector has been closed.
+func (c *Collector) Close() {
+	c.stopOnce.Do(func() { close(c.stop) })
+	<-c.done
+}
+
+// writer renders metric families in the text exposition format.
+type writer struct {
+	namespace string
+	buf       bytes.Buffer
+	name      string
+}
+
+func (w *writer) family(name, typ, help string) {
+	w.name = w.namespace + "_" + name
+	w.buf.WriteString("# HELP " + w.name + " " + help + "\n")
+	w.buf.WriteString("# TYPE " + w.name + " " + typ + "\n")
+}
+
+func (w *writer) value(suffix string, labels [][2]string, v float64) {
+	w.buf.WriteString(w.name + suffix)
+	if len(labels) > 0 {
+		w.buf.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				w.buf.WriteByte(',')
+			}
+			w.buf.WriteString(l[0] + `="` + labelEscaper.Replace(l[1]) + `"`)
+		}
+		w.buf.WriteByte('}')
+	}
+	w.buf.WriteByte(' ')
+	w.buf.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	w.buf.WriteByte('\n')
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// metricName converts a RocksDB property or statistics name such as
+// "rocksdb.estimate-num-keys" into a metric name without namespace such as
+// "estimate_num_keys".
+func metricName(name string) string {
+	name = strings.TrimPrefix(name, "rocksdb.")
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/ensure"
+	"github.com/tecbot/gorocksdb"
+)
+
+func TestCollector(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestCollector")
+	ensure.Nil(t, err)
+
+	cache := gorocksdb.NewLRUCache(1 << 20)
+	defer cache.Destroy()
+	bbto := gorocksdb.NewDefaultBlockBasedTableOptions()
+	defer bbto.Destroy()
+	bbto.SetBlockCache(cache)
+	opts := gorocksdb.NewDefaultOptions()
+	defer opts.Destroy()
+	opts.SetCreateIfMissing(true)
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.SetBlockBasedTableFactory(bbto)
+	opts.EnableStatistics()
+	stats := opts.GetStatistics()
+	defer stats.Destroy()
+
+	db, cfh, err := gorocksdb.OpenDbColumnFamilies(opts, dir, []string{"default", "guide"}, []*gorocksdb.Options{opts, opts})
+	ensure.Nil(t, err)
+	defer db.Close()
+	defer cfh[0].Destroy()
+	defer cfh[1].Destroy()
+
+	wo := gorocksdb.NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.PutCF(wo, cfh[1], []byte("key"), []byte("val")))
+
+	c := NewCollector(db, Config{
+		Namespace:      "test",
+		Interval:       -1,
+		ColumnFamilies: map[string]*gorocksdb.ColumnFamilyHandle{"default": cfh[0], "guide": cfh[1]},
+		Statistics:     stats,
+		BlockCache:     cache,
+	})
+	defer c.Close()
+
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+	body := get(t, srv.URL)
+
+	ensure.StringContains(t, body, "# TYPE test_num_entries_active_mem_table gauge\n")
+	ensure.StringContains(t, body, "test_num_entries_active_mem_table{cf=\"default\"} 0\n")
+	ensure.StringContains(t, body, "test_num_entries_active_mem_table{cf=\"guide\"} 1\n")
+	ensure.StringContains(t, body, "# TYPE test_number_keys_written_total counter\n")
+	ensure.StringContains(t, body, "test_number_keys_written_total 1\n")
+	ensure.StringContains(t, body, "# TYPE test_db_write_micros summary\n")
+	ensure.StringContains(t, body, "test_db_write_micros_count 1\n")
+	ensure.StringContains(t, body, "# TYPE test_block_cache_usage_bytes gauge\n")
+
+	// the sample is only updated by Collect
+	ensure.Nil(t, db.PutCF(wo, cfh[1], []byte("key2"), []byte("val")))
+	ensure.StringContains(t, get(t, srv.URL), "test_number_keys_written_total 1\n")
+	c.Collect()
+	ensure.StringContains(t, get(t, srv.URL), "test_number_keys_written_total 2\n")
+}
+
+func TestCollectorInterval(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestCollectorInterval")
+	ensure.Nil(t, err)
+	opts := gorocksdb.NewDefaultOptions()
+	defer opts.Destroy()
+	opts.SetCreateIfMissing(true)
+	db, err := gorocksdb.OpenDb(opts, dir)
+	ensure.Nil(t, err)
+	defer db.Close()
+
+	c := NewCollector(db, Config{Interval: 10 * time.Millisecond})
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+	ensure.StringContains(t, get(t, srv.URL), "rocksdb_num_entries_active_mem_table{cf=\"default\"} 0\n")
+
+	wo := gorocksdb.NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("key"), []byte("val")))
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		body := get(t, srv.URL)
+		if strings.Contains(body, "rocksdb_num_entries_active_mem_table{cf=\"default\"} 1\n") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("sample was not refreshed:\n%s", body)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Close()
+	c.Close()
+}
+
+func TestMetricName(t *testing.T) {
+	ensure.DeepEqual(t, metricName("rocksdb.estimate-num-keys"), "estimate_num_keys")
+	ensure.DeepEqual(t, metricName("rocksdb.block.cache.miss"), "block_cache_miss")
+	ensure.DeepEqual(t, metricName("custom.prop"), "custom_prop")
+}
+
+func get(t *testing.T, url string) string {
+	resp, err := http.Get(url)
+	ensure.Nil(t, err)
+	defer resp.Body.Close()
+	ensure.DeepEqual(t, resp.StatusCode, http.StatusOK)
+	ensure.StringContains(t, resp.Header.Get("Content-Type"), "text/plain")
+	body, err := ioutil.ReadAll(resp.Body)
+	ensure.Nil(t, err)
+	return string(body)
+}
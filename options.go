@@ -1,9 +1,13 @@
 package gorocksdb
 
+// #include <stdlib.h>
 // #include "rocksdb/c.h"
 // #include "gorocksdb.h"
 import "C"
-import "unsafe"
+import (
+	"errors"
+	"unsafe"
+)
 
 // CompressionType specifies the block compression.
 // DB contents are stored in a set of blocks, each of which holds a
@@ -18,6 +22,14 @@ const (
 	SnappyCompression = CompressionType(C.rocksdb_snappy_compression)
 	ZLibCompression   = CompressionType(C.rocksdb_zlib_compression)
 	Bz2Compression    = CompressionType(C.rocksdb_bz2_compression)
+	LZ4Compression    = CompressionType(C.rocksdb_lz4_compression)
+	LZ4HCCompression  = CompressionType(C.rocksdb_lz4hc_compression)
+	XpressCompression = CompressionType(C.rocksdb_xpress_compression)
+	ZSTDCompression   = CompressionType(C.rocksdb_zstd_compression)
+
+	// DisableCompressionOption unsets an optional compression setting,
+	// e.g. the bottommost compression.
+	DisableCompressionOption = CompressionType(0xff)
 )
 
 // CompactionStyle specifies the compaction style.
@@ -41,6 +53,26 @@ const (
 	WillneedCompactionAccessPattern   = CompactionAccessPattern(3)
 )
 
+// WALRecoveryMode specifies how the write-ahead log is replayed when a
+// database is opened after a crash.
+type WALRecoveryMode uint
+
+// WAL recovery modes.
+const (
+	// TolerateCorruptedTailRecordsRecovery tolerates incomplete records at
+	// the end of the log, e.g. from a crash during a write, and fails on
+	// other corruptions.
+	TolerateCorruptedTailRecordsRecovery = WALRecoveryMode(0)
+	// AbsoluteConsistencyRecovery fails on any corruption of the log.
+	AbsoluteConsistencyRecovery = WALRecoveryMode(1)
+	// PointInTimeRecovery stops replaying the log at the first corruption,
+	// so the database is consistent up to that point.
+	PointInTimeRecovery = WALRecoveryMode(2)
+	// SkipAnyCorruptedRecordsRecovery skips corrupted records and replays
+	// as much of the log as possible.
+	SkipAnyCorruptedRecordsRecovery = WALRecoveryMode(3)
+)
+
 // InfoLogLevel describes the log level.
 type InfoLogLevel uint
 
@@ -51,6 +83,9 @@ const (
 	WarnInfoLogLevel  = InfoLogLevel(2)
 	ErrorInfoLogLevel = InfoLogLevel(3)
 	FatalInfoLogLevel = InfoLogLevel(4)
+	// HeaderInfoLogLevel is used for the header written when the log is
+	// opened. It is always logged.
+	HeaderInfoLogLevel = InfoLogLevel(5)
 )
 
 // Options represent all of the available options when opening a database with Open.
@@ -58,13 +93,15 @@ type Options struct {
 	c *C.rocksdb_options_t
 
 	// Hold references for GC.
-	env  *Env
-	bbto *BlockBasedTableOptions
-
-	// We keep these so we can free their memory in Destroy.
+	env      *Env
+	bbto     *BlockBasedTableOptions
+	rowCache *Cache
+
+	// We keep these so we can free their memory in Destroy. Merge operators,
+	// compaction filter factories and prefix extractors are owned by the
+	// native options and any DB opened with them, so they are freed by
+	// RocksDB itself.
 	ccmp *C.rocksdb_comparator_t
-	cmo  *C.rocksdb_mergeoperator_t
-	cst  *C.rocksdb_slicetransform_t
 	ccf  *C.rocksdb_compactionfilter_t
 }
 
@@ -94,29 +131,51 @@ func (opts *Options) SetCompactionFilter(value CompactionFilter) {
 	C.rocksdb_options_set_compaction_filter(opts.c, opts.ccf)
 }
 
+// SetCompactionFilterFactory sets the factory which creates a new
+// compaction filter for each compaction. It is ignored if a compaction
+// filter is set with SetCompactionFilter.
+// Default: nil
+func (opts *Options) SetCompactionFilterFactory(value CompactionFilterFactory) {
+	var ccff *C.rocksdb_compactionfilterfactory_t
+	if nf, ok := value.(nativeCompactionFilterFactory); ok {
+		ccff = nf.c
+	} else {
+		idx := registerCompactionFilterFactory(value)
+		ccff = C.gorocksdb_compactionfilterfactory_create(C.uintptr_t(idx))
+	}
+	C.rocksdb_options_set_compaction_filter_factory(opts.c, ccff)
+}
+
 // SetComparator sets the comparator which define the order of keys in the table.
 // Default: a comparator that uses lexicographic byte-wise ordering
 func (opts *Options) SetComparator(value Comparator) {
 	if nc, ok := value.(nativeComparator); ok {
 		opts.ccmp = nc.c
 	} else {
-		idx := registerComperator(value)
+		idx := registerComparator(value)
 		opts.ccmp = C.gorocksdb_comparator_create(C.uintptr_t(idx))
 	}
 	C.rocksdb_options_set_comparator(opts.c, opts.ccmp)
 }
 
+// GetComparatorName returns the name of the comparator which defines the
+// order of keys in the table.
+func (opts *Options) GetComparatorName() string {
+	return C.GoString(C.gorocksdb_options_get_comparator_name(opts.c))
+}
+
 // SetMergeOperator sets the merge operator which will be called
 // if a merge operations are used.
 // Default: nil
 func (opts *Options) SetMergeOperator(value MergeOperator) {
+	var cmo *C.rocksdb_mergeoperator_t
 	if nmo, ok := value.(nativeMergeOperator); ok {
-		opts.cmo = nmo.c
+		cmo = nmo.c
 	} else {
 		idx := registerMergeOperator(value)
-		opts.cmo = C.gorocksdb_mergeoperator_create(C.uintptr_t(idx))
+		cmo = C.gorocksdb_mergeoperator_create(C.uintptr_t(idx))
 	}
-	C.rocksdb_options_set_merge_operator(opts.c, opts.cmo)
+	C.rocksdb_options_set_merge_operator(opts.c, cmo)
 }
 
 // A single CompactionFilter instance to call into during compaction.
@@ -139,17 +198,6 @@ func (opts *Options) SetMergeOperator(value MergeOperator) {
 //	C.rocksdb_options_set_compaction_filter(opts.c, value.filter)
 //}
 
-// This is a factory that provides compaction filter objects which allow
-// an application to modify/delete a key-value during background compaction.
-//
-// A new filter will be created on each compaction run.  If multithreaded
-// compaction is being used, each created CompactionFilter will only be used
-// from a single thread and so does not need to be thread-safe.
-//
-// Default: a factory that doesn't provide any object
-// std::shared_ptr<CompactionFilterFactory> compaction_filter_factory;
-// TODO: implement in C and Go
-
 // Version TWO of the compaction_filter_factory
 // It supports rolling compaction
 //
@@ -164,6 +212,12 @@ func (opts *Options) SetCreateIfMissing(value bool) {
 	C.rocksdb_options_set_create_if_missing(opts.c, boolToChar(value))
 }
 
+// GetCreateIfMissing returns whether the database will be created if it is
+// missing.
+func (opts *Options) GetCreateIfMissing() bool {
+	return charToBool(C.rocksdb_options_get_create_if_missing(opts.c))
+}
+
 // SetErrorIfExists specifies whether an error should be raised
 // if the database already exists.
 // Default: false
@@ -171,6 +225,12 @@ func (opts *Options) SetErrorIfExists(value bool) {
 	C.rocksdb_options_set_error_if_exists(opts.c, boolToChar(value))
 }
 
+// GetErrorIfExists returns whether an error is raised if the database already
+// exists.
+func (opts *Options) GetErrorIfExists() bool {
+	return charToBool(C.rocksdb_options_get_error_if_exists(opts.c))
+}
+
 // SetParanoidChecks enable/disable paranoid checks.
 //
 // If true, the implementation will do aggressive checking of the
@@ -186,6 +246,11 @@ func (opts *Options) SetParanoidChecks(value bool) {
 	C.rocksdb_options_set_paranoid_checks(opts.c, boolToChar(value))
 }
 
+// GetParanoidChecks returns whether paranoid checks are enabled.
+func (opts *Options) GetParanoidChecks() bool {
+	return charToBool(C.rocksdb_options_get_paranoid_checks(opts.c))
+}
+
 // SetEnv sets the specified object to interact with the environment,
 // e.g. to read/write files, schedule background work, etc.
 // Default: DefaultEnv
@@ -195,10 +260,16 @@ func (opts *Options) SetEnv(value *Env) {
 	C.rocksdb_options_set_env(opts.c, value.c)
 }
 
-// SetInfoLogLevel sets the info log level.
+// SetInfoLogLevel sets the info log level. It also applies to a logger set
+// with SetLogger.
 // Default: InfoInfoLogLevel
 func (opts *Options) SetInfoLogLevel(value InfoLogLevel) {
-	C.rocksdb_options_set_info_log_level(opts.c, C.int(value))
+	C.gorocksdb_options_set_info_log_level(opts.c, C.int(value))
+}
+
+// GetInfoLogLevel returns the info log level.
+func (opts *Options) GetInfoLogLevel() InfoLogLevel {
+	return InfoLogLevel(C.rocksdb_options_get_info_log_level(opts.c))
 }
 
 // IncreaseParallelism sets the parallelism.
@@ -260,6 +331,20 @@ func (opts *Options) SetWriteBufferSize(value int) {
 	C.rocksdb_options_set_write_buffer_size(opts.c, C.size_t(value))
 }
 
+// GetWriteBufferSize returns the amount of data to build up in memory before
+// converting to a sorted on-disk file.
+func (opts *Options) GetWriteBufferSize() int {
+	return int(C.rocksdb_options_get_write_buffer_size(opts.c))
+}
+
+// SetWriteBufferManager sets the write buffer manager which limits the
+// total memtable memory of all column families and databases it is set
+// on, in addition to the per column family write buffer size.
+// Default: nil
+func (opts *Options) SetWriteBufferManager(wbm *WriteBufferManager) {
+	C.rocksdb_options_set_write_buffer_manager(opts.c, wbm.c)
+}
+
 // SetMaxWriteBufferNumber sets the maximum number of write buffers
 // that are built up in memory.
 //
@@ -270,6 +355,12 @@ func (opts *Options) SetMaxWriteBufferNumber(value int) {
 	C.rocksdb_options_set_max_write_buffer_number(opts.c, C.int(value))
 }
 
+// GetMaxWriteBufferNumber returns the maximum number of write buffers that are
+// built up in memory.
+func (opts *Options) GetMaxWriteBufferNumber() int {
+	return int(C.rocksdb_options_get_max_write_buffer_number(opts.c))
+}
+
 // SetMinWriteBufferNumberToMerge sets the minimum number of write buffers
 // that will be merged together before writing to storage.
 //
@@ -283,6 +374,12 @@ func (opts *Options) SetMinWriteBufferNumberToMerge(value int) {
 	C.rocksdb_options_set_min_write_buffer_number_to_merge(opts.c, C.int(value))
 }
 
+// GetMinWriteBufferNumberToMerge returns the minimum number of write buffers
+// that will be merged together before writing to storage.
+func (opts *Options) GetMinWriteBufferNumberToMerge() int {
+	return int(C.rocksdb_options_get_min_write_buffer_number_to_merge(opts.c))
+}
+
 // SetMaxOpenFiles sets the number of open files that can be used by the DB.
 //
 // You may need to increase this if your database has a large working set
@@ -292,6 +389,11 @@ func (opts *Options) SetMaxOpenFiles(value int) {
 	C.rocksdb_options_set_max_open_files(opts.c, C.int(value))
 }
 
+// GetMaxOpenFiles returns the number of open files that can be used by the DB.
+func (opts *Options) GetMaxOpenFiles() int {
+	return int(C.rocksdb_options_get_max_open_files(opts.c))
+}
+
 // SetCompression sets the compression algorithm.
 // Default: SnappyCompression, which gives lightweight but fast
 // compression.
@@ -299,6 +401,11 @@ func (opts *Options) SetCompression(value CompressionType) {
 	C.rocksdb_options_set_compression(opts.c, C.int(value))
 }
 
+// GetCompression returns the compression algorithm.
+func (opts *Options) GetCompression() CompressionType {
+	return CompressionType(C.rocksdb_options_get_compression(opts.c))
+}
+
 // SetCompressionPerLevel sets different compression algorithm per level.
 //
 // Different levels can have different compression policies. There
@@ -322,10 +429,51 @@ func (opts *Options) SetMinLevelToCompress(value int) {
 	C.rocksdb_options_set_min_level_to_compress(opts.c, C.int(value))
 }
 
+// SetBottommostCompression sets the compression algorithm used for the
+// bottommost level, which usually holds most of the data. A stronger but
+// slower compression like ZSTDCompression pays off there.
+// Default: DisableCompressionOption, which uses the compression of the
+// other levels
+func (opts *Options) SetBottommostCompression(value CompressionType) {
+	C.rocksdb_options_set_bottommost_compression(opts.c, C.int(value))
+}
+
+// GetBottommostCompression returns the compression algorithm used for the
+// bottommost level.
+func (opts *Options) GetBottommostCompression() CompressionType {
+	return CompressionType(C.rocksdb_options_get_bottommost_compression(opts.c))
+}
+
 // SetCompressionOptions sets different options for compression algorithms.
 // Default: nil
 func (opts *Options) SetCompressionOptions(value *CompressionOptions) {
-	C.rocksdb_options_set_compression_options(opts.c, C.int(value.WindowBits), C.int(value.Level), C.int(value.Strategy))
+	C.rocksdb_options_set_compression_options(opts.c, C.int(value.WindowBits), C.int(value.Level), C.int(value.Strategy), C.int(value.MaxDictBytes))
+	if value.ZstdMaxTrainBytes != 0 {
+		C.rocksdb_options_set_compression_options_zstd_max_train_bytes(opts.c, C.int(value.ZstdMaxTrainBytes))
+	}
+	if value.UseZstdDictTrainer != nil {
+		C.rocksdb_options_set_compression_options_use_zstd_dict_trainer(opts.c, boolToChar(*value.UseZstdDictTrainer))
+	}
+	if value.MaxDictBufferBytes != 0 {
+		C.rocksdb_options_set_compression_options_max_dict_buffer_bytes(opts.c, C.uint64_t(value.MaxDictBufferBytes))
+	}
+}
+
+// SetBottommostCompressionOptions sets the options for the bottommost
+// compression algorithm. They are only used if a bottommost compression
+// is set.
+// Default: nil, which uses the options of the other levels
+func (opts *Options) SetBottommostCompressionOptions(value *CompressionOptions) {
+	C.rocksdb_options_set_bottommost_compression_options(opts.c, C.int(value.WindowBits), C.int(value.Level), C.int(value.Strategy), C.int(value.MaxDictBytes), boolToChar(true))
+	if value.ZstdMaxTrainBytes != 0 {
+		C.rocksdb_options_set_bottommost_compression_options_zstd_max_train_bytes(opts.c, C.int(value.ZstdMaxTrainBytes), boolToChar(true))
+	}
+	if value.UseZstdDictTrainer != nil {
+		C.rocksdb_options_set_bottommost_compression_options_use_zstd_dict_trainer(opts.c, boolToChar(*value.UseZstdDictTrainer), boolToChar(true))
+	}
+	if value.MaxDictBufferBytes != 0 {
+		C.rocksdb_options_set_bottommost_compression_options_max_dict_buffer_bytes(opts.c, C.uint64_t(value.MaxDictBufferBytes), boolToChar(true))
+	}
 }
 
 // SetPrefixExtractor sets the prefic extractor.
@@ -337,13 +485,14 @@ func (opts *Options) SetCompressionOptions(value *CompressionOptions) {
 // db.NewIterator().
 // Default: nil
 func (opts *Options) SetPrefixExtractor(value SliceTransform) {
+	var cst *C.rocksdb_slicetransform_t
 	if nst, ok := value.(nativeSliceTransform); ok {
-		opts.cst = nst.c
+		cst = nst.c
 	} else {
 		idx := registerSliceTransform(value)
-		opts.cst = C.gorocksdb_slicetransform_create(C.uintptr_t(idx))
+		cst = C.gorocksdb_slicetransform_create(C.uintptr_t(idx))
 	}
-	C.rocksdb_options_set_prefix_extractor(opts.c, opts.cst)
+	C.rocksdb_options_set_prefix_extractor(opts.c, cst)
 }
 
 // SetNumLevels sets the number of levels for this database.
@@ -352,6 +501,11 @@ func (opts *Options) SetNumLevels(value int) {
 	C.rocksdb_options_set_num_levels(opts.c, C.int(value))
 }
 
+// GetNumLevels returns the number of levels for this database.
+func (opts *Options) GetNumLevels() int {
+	return int(C.rocksdb_options_get_num_levels(opts.c))
+}
+
 // SetLevel0FileNumCompactionTrigger sets the number of files
 // to trigger level-0 compaction.
 //
@@ -362,6 +516,12 @@ func (opts *Options) SetLevel0FileNumCompactionTrigger(value int) {
 	C.rocksdb_options_set_level0_file_num_compaction_trigger(opts.c, C.int(value))
 }
 
+// GetLevel0FileNumCompactionTrigger returns the number of files to trigger
+// level-0 compaction.
+func (opts *Options) GetLevel0FileNumCompactionTrigger() int {
+	return int(C.rocksdb_options_get_level0_file_num_compaction_trigger(opts.c))
+}
+
 // SetLevel0SlowdownWritesTrigger sets the soft limit on number of level-0 files.
 //
 // We start slowing down writes at this point.
@@ -372,6 +532,12 @@ func (opts *Options) SetLevel0SlowdownWritesTrigger(value int) {
 	C.rocksdb_options_set_level0_slowdown_writes_trigger(opts.c, C.int(value))
 }
 
+// GetLevel0SlowdownWritesTrigger returns the soft limit on number of level-0
+// files.
+func (opts *Options) GetLevel0SlowdownWritesTrigger() int {
+	return int(C.rocksdb_options_get_level0_slowdown_writes_trigger(opts.c))
+}
+
 // SetLevel0StopWritesTrigger sets the maximum number of level-0 files.
 // We stop writes at this point.
 // Default: 12
@@ -379,6 +545,11 @@ func (opts *Options) SetLevel0StopWritesTrigger(value int) {
 	C.rocksdb_options_set_level0_stop_writes_trigger(opts.c, C.int(value))
 }
 
+// GetLevel0StopWritesTrigger returns the maximum number of level-0 files.
+func (opts *Options) GetLevel0StopWritesTrigger() int {
+	return int(C.rocksdb_options_get_level0_stop_writes_trigger(opts.c))
+}
+
 // SetMaxMemCompactionLevel sets the maximum level
 // to which a new compacted memtable is pushed if it does not create overlap.
 //
@@ -388,8 +559,9 @@ func (opts *Options) SetLevel0StopWritesTrigger(value int) {
 // the largest level since that can generate a lot of wasted disk
 // space if the same key space is being repeatedly overwritten.
 // Default: 2
+//
+// Deprecated: RocksDB removed this option, this does nothing.
 func (opts *Options) SetMaxMemCompactionLevel(value int) {
-	C.rocksdb_options_set_max_mem_compaction_level(opts.c, C.int(value))
 }
 
 // SetTargetFileSizeBase sets the target file size for compaction.
@@ -407,12 +579,23 @@ func (opts *Options) SetTargetFileSizeBase(value uint64) {
 	C.rocksdb_options_set_target_file_size_base(opts.c, C.uint64_t(value))
 }
 
+// GetTargetFileSizeBase returns the target file size for compaction.
+func (opts *Options) GetTargetFileSizeBase() uint64 {
+	return uint64(C.rocksdb_options_get_target_file_size_base(opts.c))
+}
+
 // SetTargetFileSizeMultiplier sets the target file size multiplier for compaction.
 // Default: 1
 func (opts *Options) SetTargetFileSizeMultiplier(value int) {
 	C.rocksdb_options_set_target_file_size_multiplier(opts.c, C.int(value))
 }
 
+// GetTargetFileSizeMultiplier returns the target file size multiplier for
+// compaction.
+func (opts *Options) GetTargetFileSizeMultiplier() int {
+	return int(C.rocksdb_options_get_target_file_size_multiplier(opts.c))
+}
+
 // SetMaxBytesForLevelBase sets the maximum total data size for a level.
 //
 // It is the max total for level-1.
@@ -428,10 +611,20 @@ func (opts *Options) SetMaxBytesForLevelBase(value uint64) {
 	C.rocksdb_options_set_max_bytes_for_level_base(opts.c, C.uint64_t(value))
 }
 
+// GetMaxBytesForLevelBase returns the maximum total data size for level-1.
+func (opts *Options) GetMaxBytesForLevelBase() uint64 {
+	return uint64(C.rocksdb_options_get_max_bytes_for_level_base(opts.c))
+}
+
 // SetMaxBytesForLevelMultiplier sets the max Bytes for level multiplier.
 // Default: 10
-func (opts *Options) SetMaxBytesForLevelMultiplier(value int) {
-	C.rocksdb_options_set_max_bytes_for_level_multiplier(opts.c, C.int(value))
+func (opts *Options) SetMaxBytesForLevelMultiplier(value float64) {
+	C.rocksdb_options_set_max_bytes_for_level_multiplier(opts.c, C.double(value))
+}
+
+// GetMaxBytesForLevelMultiplier returns the max bytes for level multiplier.
+func (opts *Options) GetMaxBytesForLevelMultiplier() float64 {
+	return float64(C.rocksdb_options_get_max_bytes_for_level_multiplier(opts.c))
 }
 
 // SetMaxBytesForLevelMultiplierAdditional sets different max-size multipliers
@@ -456,8 +649,10 @@ func (opts *Options) SetMaxBytesForLevelMultiplierAdditional(value []int) {
 // if it would make the total compaction cover more than
 // (expanded_compaction_factor * targetFileSizeLevel()) many bytes.
 // Default: 25
+//
+// Deprecated: RocksDB replaced this option with max_compaction_bytes, which
+// can be set with GetOptionsFromString. This does nothing.
 func (opts *Options) SetExpandedCompactionFactor(value int) {
-	C.rocksdb_options_set_expanded_compaction_factor(opts.c, C.int(value))
 }
 
 // SetSourceCompactionFactor sets the maximum number of bytes
@@ -468,16 +663,20 @@ func (opts *Options) SetExpandedCompactionFactor(value int) {
 // for compaction to exceed
 // (source_compaction_factor * targetFileSizeLevel()) many bytes.
 // Default: 1
+//
+// Deprecated: RocksDB replaced this option with max_compaction_bytes, which
+// can be set with GetOptionsFromString. This does nothing.
 func (opts *Options) SetSourceCompactionFactor(value int) {
-	C.rocksdb_options_set_source_compaction_factor(opts.c, C.int(value))
 }
 
 // SetMaxGrandparentOverlapFactor sets the maximum bytes
 // of overlaps in grandparent (i.e., level+2) before we
 // stop building a single file in a level->level+1 compaction.
 // Default: 10
+//
+// Deprecated: RocksDB replaced this option with max_compaction_bytes, which
+// can be set with GetOptionsFromString. This does nothing.
 func (opts *Options) SetMaxGrandparentOverlapFactor(value int) {
-	C.rocksdb_options_set_max_grandparent_overlap_factor(opts.c, C.int(value))
 }
 
 // SetDisableDataSync enable/disable data sync.
@@ -488,8 +687,9 @@ func (opts *Options) SetMaxGrandparentOverlapFactor(value int) {
 // of data. Once the bulk-loading is complete, please issue a
 // sync to the OS to flush all dirty buffers to stable storage.
 // Default: false
+//
+// Deprecated: RocksDB removed this option, this does nothing.
 func (opts *Options) SetDisableDataSync(value bool) {
-	C.rocksdb_options_set_disable_data_sync(opts.c, C.int(btoi(value)))
 }
 
 // SetUseFsync enable/disable fsync.
@@ -503,6 +703,11 @@ func (opts *Options) SetUseFsync(value bool) {
 	C.rocksdb_options_set_use_fsync(opts.c, C.int(btoi(value)))
 }
 
+// GetUseFsync returns whether fsync is used instead of fdatasync.
+func (opts *Options) GetUseFsync() bool {
+	return C.rocksdb_options_get_use_fsync(opts.c) != 0
+}
+
 // SetDbLogDir specifies the absolute info LOG dir.
 //
 // If it is empty, the log files will be in the same dir as data.
@@ -539,6 +744,12 @@ func (opts *Options) SetDeleteObsoleteFilesPeriodMicros(value uint64) {
 	C.rocksdb_options_set_delete_obsolete_files_period_micros(opts.c, C.uint64_t(value))
 }
 
+// GetDeleteObsoleteFilesPeriodMicros returns the periodicity when obsolete
+// files get deleted.
+func (opts *Options) GetDeleteObsoleteFilesPeriodMicros() uint64 {
+	return uint64(C.rocksdb_options_get_delete_obsolete_files_period_micros(opts.c))
+}
+
 // SetMaxBackgroundCompactions sets the maximum number of
 // concurrent background jobs, submitted to
 // the default LOW priority thread pool
@@ -547,6 +758,12 @@ func (opts *Options) SetMaxBackgroundCompactions(value int) {
 	C.rocksdb_options_set_max_background_compactions(opts.c, C.int(value))
 }
 
+// GetMaxBackgroundCompactions returns the maximum number of concurrent
+// background compaction jobs.
+func (opts *Options) GetMaxBackgroundCompactions() int {
+	return int(C.rocksdb_options_get_max_background_compactions(opts.c))
+}
+
 // SetMaxBackgroundFlushes sets the maximum number of
 // concurrent background memtable flush jobs, submitted to
 // the HIGH priority thread pool.
@@ -563,6 +780,12 @@ func (opts *Options) SetMaxBackgroundFlushes(value int) {
 	C.rocksdb_options_set_max_background_flushes(opts.c, C.int(value))
 }
 
+// GetMaxBackgroundFlushes returns the maximum number of concurrent background
+// memtable flush jobs.
+func (opts *Options) GetMaxBackgroundFlushes() int {
+	return int(C.rocksdb_options_get_max_background_flushes(opts.c))
+}
+
 // SetMaxLogFileSize sets the maximal size of the info log file.
 //
 // If the log file is larger than `max_log_file_size`, a new info log
@@ -573,6 +796,11 @@ func (opts *Options) SetMaxLogFileSize(value int) {
 	C.rocksdb_options_set_max_log_file_size(opts.c, C.size_t(value))
 }
 
+// GetMaxLogFileSize returns the maximal size of the info log file.
+func (opts *Options) GetMaxLogFileSize() int {
+	return int(C.rocksdb_options_get_max_log_file_size(opts.c))
+}
+
 // SetLogFileTimeToRoll sets the time for the info log file to roll (in seconds).
 //
 // If specified with non-zero value, log file will be rolled
@@ -582,12 +810,23 @@ func (opts *Options) SetLogFileTimeToRoll(value int) {
 	C.rocksdb_options_set_log_file_time_to_roll(opts.c, C.size_t(value))
 }
 
+// GetLogFileTimeToRoll returns the time for the info log file to roll (in
+// seconds).
+func (opts *Options) GetLogFileTimeToRoll() int {
+	return int(C.rocksdb_options_get_log_file_time_to_roll(opts.c))
+}
+
 // SetKeepLogFileNum sets the maximal info log files to be kept.
 // Default: 1000
 func (opts *Options) SetKeepLogFileNum(value int) {
 	C.rocksdb_options_set_keep_log_file_num(opts.c, C.size_t(value))
 }
 
+// GetKeepLogFileNum returns the maximal info log files to be kept.
+func (opts *Options) GetKeepLogFileNum() int {
+	return int(C.rocksdb_options_get_keep_log_file_num(opts.c))
+}
+
 // SetSoftRateLimit sets the soft rate limit.
 //
 // Puts are delayed 0-1 ms when any level has a compaction score that exceeds
@@ -595,8 +834,11 @@ func (opts *Options) SetKeepLogFileNum(value int) {
 // CONSTRAINT: soft_rate_limit <= hard_rate_limit. If this constraint does not
 // hold, RocksDB will set soft_rate_limit = hard_rate_limit
 // Default: 0.0 (disabled)
+//
+// Deprecated: RocksDB replaced this option with
+// soft_pending_compaction_bytes_limit, which can be set with
+// GetOptionsFromString. This does nothing.
 func (opts *Options) SetSoftRateLimit(value float64) {
-	C.rocksdb_options_set_soft_rate_limit(opts.c, C.double(value))
 }
 
 // SetHardRateLimit sets the hard rate limit.
@@ -604,16 +846,20 @@ func (opts *Options) SetSoftRateLimit(value float64) {
 // Puts are delayed 1ms at a time when any level has a compaction score that
 // exceeds hard_rate_limit. This is ignored when <= 1.0.
 // Default: 0.0 (disabled)
+//
+// Deprecated: RocksDB replaced this option with
+// hard_pending_compaction_bytes_limit, which can be set with
+// GetOptionsFromString. This does nothing.
 func (opts *Options) SetHardRateLimit(value float64) {
-	C.rocksdb_options_set_hard_rate_limit(opts.c, C.double(value))
 }
 
 // SetRateLimitDelayMaxMilliseconds sets the max time
 // a put will be stalled when hard_rate_limit is enforced.
 // If 0, then there is no limit.
 // Default: 1000
+//
+// Deprecated: RocksDB removed this option, this does nothing.
 func (opts *Options) SetRateLimitDelayMaxMilliseconds(value uint) {
-	C.rocksdb_options_set_rate_limit_delay_max_milliseconds(opts.c, C.uint(value))
 }
 
 // SetMaxManifestFileSize sets the maximal manifest file size until is rolled over.
@@ -623,12 +869,23 @@ func (opts *Options) SetMaxManifestFileSize(value uint64) {
 	C.rocksdb_options_set_max_manifest_file_size(opts.c, C.size_t(value))
 }
 
+// GetMaxManifestFileSize returns the maximal manifest file size until is
+// rolled over.
+func (opts *Options) GetMaxManifestFileSize() uint64 {
+	return uint64(C.rocksdb_options_get_max_manifest_file_size(opts.c))
+}
+
 // SetTableCacheNumshardbits sets the number of shards used for table cache.
 // Default: 4
 func (opts *Options) SetTableCacheNumshardbits(value int) {
 	C.rocksdb_options_set_table_cache_numshardbits(opts.c, C.int(value))
 }
 
+// GetTableCacheNumshardbits returns the number of shards used for table cache.
+func (opts *Options) GetTableCacheNumshardbits() int {
+	return int(C.rocksdb_options_get_table_cache_numshardbits(opts.c))
+}
+
 // SetTableCacheRemoveScanCountLimit sets the count limit during a scan.
 //
 // During data eviction of table's LRU cache, it would be inefficient
@@ -638,8 +895,18 @@ func (opts *Options) SetTableCacheNumshardbits(value int) {
 // and if not enough space releases after scanning the number of
 // elements specified by this parameter, we will remove items in LRU order.
 // Default: 16
+//
+// Deprecated: RocksDB removed this option, this does nothing.
 func (opts *Options) SetTableCacheRemoveScanCountLimit(value int) {
-	C.rocksdb_options_set_table_cache_remove_scan_count_limit(opts.c, C.int(value))
+}
+
+// SetRowCache sets the cache for uncompressed key-value pairs read by
+// point lookups. It saves decoding blocks for hot keys but, unlike the
+// block cache, is not used by iterators.
+// Default: nil
+func (opts *Options) SetRowCache(cache *Cache) {
+	opts.rowCache = cache
+	C.rocksdb_options_set_row_cache(opts.c, cache.c)
 }
 
 // SetArenaBlockSize sets the size of one block in arena memory allocation.
@@ -651,6 +918,11 @@ func (opts *Options) SetArenaBlockSize(value int) {
 	C.rocksdb_options_set_arena_block_size(opts.c, C.size_t(value))
 }
 
+// GetArenaBlockSize returns the size of one block in arena memory allocation.
+func (opts *Options) GetArenaBlockSize() int {
+	return int(C.rocksdb_options_get_arena_block_size(opts.c))
+}
+
 // SetDisableAutoCompactions enable/disable automatic compactions.
 //
 // Manual compactions can still be issued on this database.
@@ -659,6 +931,12 @@ func (opts *Options) SetDisableAutoCompactions(value bool) {
 	C.rocksdb_options_set_disable_auto_compactions(opts.c, C.int(btoi(value)))
 }
 
+// GetDisableAutoCompactions returns whether automatic compactions are
+// disabled.
+func (opts *Options) GetDisableAutoCompactions() bool {
+	return charToBool(C.rocksdb_options_get_disable_auto_compactions(opts.c))
+}
+
 // SetWALTtlSeconds sets the WAL ttl in seconds.
 //
 // The following two options affect how archived logs will be deleted.
@@ -678,6 +956,11 @@ func (opts *Options) SetWALTtlSeconds(value uint64) {
 	C.rocksdb_options_set_WAL_ttl_seconds(opts.c, C.uint64_t(value))
 }
 
+// GetWALTtlSeconds returns the WAL ttl in seconds.
+func (opts *Options) GetWALTtlSeconds() uint64 {
+	return uint64(C.rocksdb_options_get_WAL_ttl_seconds(opts.c))
+}
+
 // SetWalSizeLimitMb sets the WAL size limit in MB.
 //
 // If total size of WAL files is greater then wal_size_limit_mb,
@@ -687,6 +970,39 @@ func (opts *Options) SetWalSizeLimitMb(value uint64) {
 	C.rocksdb_options_set_WAL_size_limit_MB(opts.c, C.uint64_t(value))
 }
 
+// GetWalSizeLimitMb returns the WAL size limit in MB.
+func (opts *Options) GetWalSizeLimitMb() uint64 {
+	return uint64(C.rocksdb_options_get_WAL_size_limit_MB(opts.c))
+}
+
+// SetManualWALFlush specifies whether writes stay in the in-memory WAL
+// buffer until DB.FlushWAL is called, instead of being written to the WAL
+// file with every write. This allows many writes to share one write and
+// sync of the WAL file.
+// Default: false
+func (opts *Options) SetManualWALFlush(value bool) {
+	C.rocksdb_options_set_manual_wal_flush(opts.c, boolToChar(value))
+}
+
+// GetManualWALFlush returns whether the WAL is only written on
+// DB.FlushWAL.
+func (opts *Options) GetManualWALFlush() bool {
+	return charToBool(C.rocksdb_options_get_manual_wal_flush(opts.c))
+}
+
+// SetWALRecoveryMode sets how the WAL is replayed when the database is
+// opened after a crash.
+// Default: PointInTimeRecovery
+func (opts *Options) SetWALRecoveryMode(mode WALRecoveryMode) {
+	C.rocksdb_options_set_wal_recovery_mode(opts.c, C.int(mode))
+}
+
+// GetWALRecoveryMode returns how the WAL is replayed when the database is
+// opened.
+func (opts *Options) GetWALRecoveryMode() WALRecoveryMode {
+	return WALRecoveryMode(C.rocksdb_options_get_wal_recovery_mode(opts.c))
+}
+
 // SetManifestPreallocationSize sets the number of bytes
 // to preallocate (via fallocate) the manifest files.
 //
@@ -698,19 +1014,29 @@ func (opts *Options) SetManifestPreallocationSize(value int) {
 	C.rocksdb_options_set_manifest_preallocation_size(opts.c, C.size_t(value))
 }
 
+// GetManifestPreallocationSize returns the number of bytes to preallocate for
+// the manifest files.
+func (opts *Options) GetManifestPreallocationSize() int {
+	return int(C.rocksdb_options_get_manifest_preallocation_size(opts.c))
+}
+
 // SetPurgeRedundantKvsWhileFlush enable/disable purging of
 // duplicate/deleted keys when a memtable is flushed to storage.
 // Default: true
+//
+// Deprecated: RocksDB removed this option, this does nothing.
 func (opts *Options) SetPurgeRedundantKvsWhileFlush(value bool) {
-	C.rocksdb_options_set_purge_redundant_kvs_while_flush(opts.c, boolToChar(value))
 }
 
 // SetAllowOsBuffer enable/disable os buffer.
 //
 // Data being read from file storage may be buffered in the OS
 // Default: true
+//
+// Deprecated: RocksDB replaced this option with use_direct_reads and
+// use_direct_io_for_flush_and_compaction, which can be set with
+// GetOptionsFromString. This does nothing.
 func (opts *Options) SetAllowOsBuffer(value bool) {
-	C.rocksdb_options_set_allow_os_buffer(opts.c, boolToChar(value))
 }
 
 // SetAllowMmapReads enable/disable mmap reads for reading sst tables.
@@ -719,24 +1045,43 @@ func (opts *Options) SetAllowMmapReads(value bool) {
 	C.rocksdb_options_set_allow_mmap_reads(opts.c, boolToChar(value))
 }
 
+// GetAllowMmapReads returns whether mmap reads are used for reading sst
+// tables.
+func (opts *Options) GetAllowMmapReads() bool {
+	return charToBool(C.rocksdb_options_get_allow_mmap_reads(opts.c))
+}
+
 // SetAllowMmapWrites enable/disable mmap writes for writing sst tables.
 // Default: true
 func (opts *Options) SetAllowMmapWrites(value bool) {
 	C.rocksdb_options_set_allow_mmap_writes(opts.c, boolToChar(value))
 }
 
+// GetAllowMmapWrites returns whether mmap writes are used for writing sst
+// tables.
+func (opts *Options) GetAllowMmapWrites() bool {
+	return charToBool(C.rocksdb_options_get_allow_mmap_writes(opts.c))
+}
+
 // SetIsFdCloseOnExec enable/dsiable child process inherit open files.
 // Default: true
 func (opts *Options) SetIsFdCloseOnExec(value bool) {
 	C.rocksdb_options_set_is_fd_close_on_exec(opts.c, boolToChar(value))
 }
 
+// GetIsFdCloseOnExec returns whether open files are closed on exec.
+func (opts *Options) GetIsFdCloseOnExec() bool {
+	return charToBool(C.rocksdb_options_get_is_fd_close_on_exec(opts.c))
+}
+
 // SetSkipLogErrorOnRecovery enable/disable skipping of
 // log corruption error on recovery (If client is ok with
 // losing most recent changes)
 // Default: false
+//
+// Deprecated: RocksDB replaced this option with the WAL recovery mode, see
+// SetWALRecoveryMode. This does nothing.
 func (opts *Options) SetSkipLogErrorOnRecovery(value bool) {
-	C.rocksdb_options_set_skip_log_error_on_recovery(opts.c, boolToChar(value))
 }
 
 // SetStatsDumpPeriodSec sets the stats dump period in seconds.
@@ -747,6 +1092,11 @@ func (opts *Options) SetStatsDumpPeriodSec(value uint) {
 	C.rocksdb_options_set_stats_dump_period_sec(opts.c, C.uint(value))
 }
 
+// GetStatsDumpPeriodSec returns the stats dump period in seconds.
+func (opts *Options) GetStatsDumpPeriodSec() uint {
+	return uint(C.rocksdb_options_get_stats_dump_period_sec(opts.c))
+}
+
 // SetAdviseRandomOnOpen specifies whether we will hint the underlying
 // file system that the file access pattern is random, when a sst file is opened.
 // Default: true
@@ -754,13 +1104,20 @@ func (opts *Options) SetAdviseRandomOnOpen(value bool) {
 	C.rocksdb_options_set_advise_random_on_open(opts.c, boolToChar(value))
 }
 
+// GetAdviseRandomOnOpen returns whether a random access pattern is hinted when
+// a sst file is opened.
+func (opts *Options) GetAdviseRandomOnOpen() bool {
+	return charToBool(C.rocksdb_options_get_advise_random_on_open(opts.c))
+}
+
 // SetAccessHintOnCompactionStart specifies the file access pattern
 // once a compaction is started.
 //
 // It will be applied to all input files of a compaction.
 // Default: NormalCompactionAccessPattern
+//
+// Deprecated: RocksDB removed this option, this does nothing.
 func (opts *Options) SetAccessHintOnCompactionStart(value CompactionAccessPattern) {
-	C.rocksdb_options_set_access_hint_on_compaction_start(opts.c, C.int(value))
 }
 
 // SetUseAdaptiveMutex enable/disable adaptive mutex, which spins
@@ -774,6 +1131,11 @@ func (opts *Options) SetUseAdaptiveMutex(value bool) {
 	C.rocksdb_options_set_use_adaptive_mutex(opts.c, boolToChar(value))
 }
 
+// GetUseAdaptiveMutex returns whether the adaptive mutex is used.
+func (opts *Options) GetUseAdaptiveMutex() bool {
+	return charToBool(C.rocksdb_options_get_use_adaptive_mutex(opts.c))
+}
+
 // SetBytesPerSync sets the bytes per sync.
 //
 // Allows OS to incrementally sync files to disk while they are being
@@ -784,12 +1146,22 @@ func (opts *Options) SetBytesPerSync(value uint64) {
 	C.rocksdb_options_set_bytes_per_sync(opts.c, C.uint64_t(value))
 }
 
+// GetBytesPerSync returns the bytes per sync.
+func (opts *Options) GetBytesPerSync() uint64 {
+	return uint64(C.rocksdb_options_get_bytes_per_sync(opts.c))
+}
+
 // SetCompactionStyle sets the compaction style.
 // Default: LevelCompactionStyle
 func (opts *Options) SetCompactionStyle(value CompactionStyle) {
 	C.rocksdb_options_set_compaction_style(opts.c, C.int(value))
 }
 
+// GetCompactionStyle returns the compaction style.
+func (opts *Options) GetCompactionStyle() CompactionStyle {
+	return CompactionStyle(C.rocksdb_options_get_compaction_style(opts.c))
+}
+
 // SetUniversalCompactionOptions sets the options needed
 // to support Universal Style compactions.
 // Default: nil
@@ -808,8 +1180,9 @@ func (opts *Options) SetFIFOCompactionOptions(value *FIFOCompactionOptions) {
 // If true, compaction will verify checksum on every read that happens
 // as part of compaction
 // Default: true
+//
+// Deprecated: RocksDB removed this option, this does nothing.
 func (opts *Options) SetVerifyChecksumsInCompaction(value bool) {
-	C.rocksdb_options_set_verify_checksums_in_compaction(opts.c, boolToChar(value))
 }
 
 // SetFilterDeletes enable/disable filtering of deleted keys.
@@ -819,8 +1192,9 @@ func (opts *Options) SetVerifyChecksumsInCompaction(value bool) {
 // the delete is a noop. KeyMayExist only incurs in-memory look up.
 // This optimization avoids writing the delete to storage when appropriate.
 // Default: false
+//
+// Deprecated: RocksDB removed this option, this does nothing.
 func (opts *Options) SetFilterDeletes(value bool) {
-	C.rocksdb_options_set_filter_deletes(opts.c, boolToChar(value))
 }
 
 // SetMaxSequentialSkipInIterations specifies whether an iteration->Next()
@@ -833,6 +1207,12 @@ func (opts *Options) SetMaxSequentialSkipInIterations(value uint64) {
 	C.rocksdb_options_set_max_sequential_skip_in_iterations(opts.c, C.uint64_t(value))
 }
 
+// GetMaxSequentialSkipInIterations returns the number of keys with the same
+// user key that will be sequentially skipped before a reseek is issued.
+func (opts *Options) GetMaxSequentialSkipInIterations() uint64 {
+	return uint64(C.rocksdb_options_get_max_sequential_skip_in_iterations(opts.c))
+}
+
 // SetInplaceUpdateSupport enable/disable thread-safe inplace updates.
 //
 // Requires updates if
@@ -844,25 +1224,43 @@ func (opts *Options) SetInplaceUpdateSupport(value bool) {
 	C.rocksdb_options_set_inplace_update_support(opts.c, boolToChar(value))
 }
 
+// GetInplaceUpdateSupport returns whether thread-safe inplace updates are
+// enabled.
+func (opts *Options) GetInplaceUpdateSupport() bool {
+	return charToBool(C.rocksdb_options_get_inplace_update_support(opts.c))
+}
+
 // SetInplaceUpdateNumLocks sets the number of locks used for inplace update.
 // Default: 10000, if inplace_update_support = true, else 0.
 func (opts *Options) SetInplaceUpdateNumLocks(value int) {
 	C.rocksdb_options_set_inplace_update_num_locks(opts.c, C.size_t(value))
 }
 
+// GetInplaceUpdateNumLocks returns the number of locks used for inplace
+// update.
+func (opts *Options) GetInplaceUpdateNumLocks() int {
+	return int(C.rocksdb_options_get_inplace_update_num_locks(opts.c))
+}
+
 // SetMemtablePrefixBloomBits sets the bloom bits for prefix extractor.
 //
 // If prefix_extractor is set and bloom_bits is not 0, create prefix bloom
 // for memtable.
 // Default: 0
+//
+// Deprecated: RocksDB replaced this option with
+// memtable_prefix_bloom_size_ratio, which can be set with
+// GetOptionsFromString. This does nothing.
 func (opts *Options) SetMemtablePrefixBloomBits(value uint32) {
-	C.rocksdb_options_set_memtable_prefix_bloom_bits(opts.c, C.uint32_t(value))
 }
 
 // SetMemtablePrefixBloomProbes sets the number of hash probes per key.
 // Default: 6
+//
+// Deprecated: RocksDB replaced this option with
+// memtable_prefix_bloom_size_ratio, which can be set with
+// GetOptionsFromString. This does nothing.
 func (opts *Options) SetMemtablePrefixBloomProbes(value uint32) {
-	C.rocksdb_options_set_memtable_prefix_bloom_probes(opts.c, C.uint32_t(value))
 }
 
 // SetBloomLocality sets the bloom locality.
@@ -880,6 +1278,11 @@ func (opts *Options) SetBloomLocality(value uint32) {
 	C.rocksdb_options_set_bloom_locality(opts.c, C.uint32_t(value))
 }
 
+// GetBloomLocality returns the bloom locality.
+func (opts *Options) GetBloomLocality() uint32 {
+	return uint32(C.rocksdb_options_get_bloom_locality(opts.c))
+}
+
 // SetMaxSuccessiveMerges sets the maximum number of
 // successive merge operations on a key in the memtable.
 //
@@ -893,6 +1296,12 @@ func (opts *Options) SetMaxSuccessiveMerges(value int) {
 	C.rocksdb_options_set_max_successive_merges(opts.c, C.size_t(value))
 }
 
+// GetMaxSuccessiveMerges returns the maximum number of successive merge
+// operations on a key in the memtable.
+func (opts *Options) GetMaxSuccessiveMerges() int {
+	return int(C.rocksdb_options_get_max_successive_merges(opts.c))
+}
+
 // SetMinPartialMergeOperands sets the number of partial merge operands
 // to accumulate before partial merge will be performed.
 //
@@ -900,11 +1309,29 @@ func (opts *Options) SetMaxSuccessiveMerges(value int) {
 // is less than min_partial_merge_operands.
 // If min_partial_merge_operands < 2, then it will be treated as 2.
 // Default: 2
+//
+// Deprecated: RocksDB removed this option, this does nothing.
 func (opts *Options) SetMinPartialMergeOperands(value uint32) {
-	C.rocksdb_options_set_min_partial_merge_operands(opts.c, C.uint32_t(value))
+}
+
+// SetRateLimiter sets the rate limiter which controls the write rate of
+// flushes and compactions. The same rate limiter can be set on the options
+// of several databases.
+// Default: nil
+func (opts *Options) SetRateLimiter(rateLimiter *RateLimiter) {
+	C.rocksdb_options_set_ratelimiter(opts.c, rateLimiter.c)
+}
+
+// SetSstFileManager sets the SstFileManager which tracks the SST files and
+// controls their disk space usage and deletion. The same SstFileManager can
+// be set on the options of several databases.
+// Default: nil
+func (opts *Options) SetSstFileManager(sstFileManager *SstFileManager) {
+	C.rocksdb_options_set_sst_file_manager(opts.c, sstFileManager.c)
 }
 
 // EnableStatistics enable statistics.
+// The collected statistics can be read with GetStatistics.
 func (opts *Options) EnableStatistics() {
 	C.rocksdb_options_enable_statistics(opts.c)
 }
@@ -966,7 +1393,7 @@ func (opts *Options) SetHashLinkListRep(bucketCount int) {
 // indexSparseness: inside each prefix, need to build one index record for how
 //                  many keys for binary search inside each hash bucket.
 func (opts *Options) SetPlainTableFactory(keyLen uint32, bloomBitsPerKey int, hashTableRatio float64, indexSparseness int) {
-	C.rocksdb_options_set_plain_table_factory(opts.c, C.uint32_t(keyLen), C.int(bloomBitsPerKey), C.double(hashTableRatio), C.size_t(indexSparseness))
+	C.rocksdb_options_set_plain_table_factory(opts.c, C.uint32_t(keyLen), C.int(bloomBitsPerKey), C.double(hashTableRatio), C.size_t(indexSparseness), 0, C.char(0), 0, 0)
 }
 
 // SetCreateIfMissingColumnFamilies specifies whether the column families
@@ -975,28 +1402,46 @@ func (opts *Options) SetCreateIfMissingColumnFamilies(value bool) {
 	C.rocksdb_options_set_create_missing_column_families(opts.c, boolToChar(value))
 }
 
+// GetCreateIfMissingColumnFamilies returns whether the column families will be
+// created if they are missing.
+func (opts *Options) GetCreateIfMissingColumnFamilies() bool {
+	return charToBool(C.rocksdb_options_get_create_missing_column_families(opts.c))
+}
+
 // SetBlockBasedTableFactory sets the block based table factory.
 func (opts *Options) SetBlockBasedTableFactory(value *BlockBasedTableOptions) {
 	opts.bbto = value
 	C.rocksdb_options_set_block_based_table_factory(opts.c, value.c)
 }
 
+// ToMap returns the effective value of all options, including the ones of
+// the table factory, as a map of option names to values in the format
+// understood by GetOptionsFromMap. Nested options, like the ones of
+// "block_based_table_factory", are returned as a single string.
+func (opts *Options) ToMap() (map[string]string, error) {
+	var cErr *C.char
+	cValue := C.gorocksdb_options_to_string(opts.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, errors.New(C.GoString(cErr))
+	}
+	defer C.free(unsafe.Pointer(cValue))
+	return parseOptionsString(C.GoString(cValue)), nil
+}
+
 // Destroy deallocates the Options object.
 func (opts *Options) Destroy() {
 	C.rocksdb_options_destroy(opts.c)
 	if opts.ccmp != nil {
 		C.rocksdb_comparator_destroy(opts.ccmp)
 	}
-	if opts.cmo != nil {
-		C.rocksdb_mergeoperator_destroy(opts.cmo)
-	}
-	if opts.cst != nil {
-		C.rocksdb_slicetransform_destroy(opts.cst)
-	}
 	if opts.ccf != nil {
 		C.rocksdb_compactionfilter_destroy(opts.ccf)
 	}
 	opts.c = nil
 	opts.env = nil
 	opts.bbto = nil
+	opts.rowCache = nil
+	opts.ccmp = nil
+	opts.ccf = nil
 }
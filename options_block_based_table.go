@@ -1,16 +1,63 @@
 package gorocksdb
 
+// #include <stdlib.h>
 // #include "rocksdb/c.h"
 // #include "gorocksdb.h"
 import "C"
+import (
+	"errors"
+	"unsafe"
+)
+
+// IndexType specifies the index type of the table files.
+type IndexType uint
+
+// Index types.
+const (
+	// BinarySearchIndexType is a space efficient index block that is
+	// optimized for binary-search-based index.
+	BinarySearchIndexType = IndexType(C.rocksdb_block_based_table_index_type_binary_search)
+	// HashSearchIndexType is a hash index on top of the binary search
+	// index. It requires a prefix extractor to be set on the options.
+	HashSearchIndexType = IndexType(C.rocksdb_block_based_table_index_type_hash_search)
+	// TwoLevelIndexSearchIndexType is a two-level index implementation.
+	// Both levels are binary search indexes and only the top level index
+	// has to be held in memory.
+	TwoLevelIndexSearchIndexType = IndexType(C.rocksdb_block_based_table_index_type_two_level_index_search)
+)
+
+// DataBlockIndexType specifies how keys are looked up within a data block.
+type DataBlockIndexType uint
+
+// Data block index types.
+const (
+	// DataBlockBinarySearchIndexType uses binary search over the restart
+	// points.
+	DataBlockBinarySearchIndexType = DataBlockIndexType(C.rocksdb_block_based_table_data_block_index_type_binary_search)
+	// DataBlockBinaryAndHashIndexType adds a hash index to each data block,
+	// which speeds up point lookups.
+	DataBlockBinaryAndHashIndexType = DataBlockIndexType(C.rocksdb_block_based_table_data_block_index_type_binary_search_and_hash)
+)
+
+// ChecksumType specifies the checksum used to verify the blocks of the
+// table files.
+type ChecksumType uint
+
+// Checksum types.
+const (
+	NoChecksum       = ChecksumType(0)
+	CRC32cChecksum   = ChecksumType(1)
+	XXHashChecksum   = ChecksumType(2)
+	XXHash64Checksum = ChecksumType(3)
+	XXH3Checksum     = ChecksumType(4)
+)
 
 // BlockBasedTableOptions represents block-based table options.
 type BlockBasedTableOptions struct {
 	c *C.rocksdb_block_based_table_options_t
 
 	// Hold references for GC.
-	cache     *Cache
-	compCache *Cache
+	cache *Cache
 
 	// We keep these so we can free their memory in Destroy.
 	cFp *C.rocksdb_filterpolicy_t
@@ -31,7 +78,20 @@ func (opts *BlockBasedTableOptions) Destroy() {
 	C.rocksdb_block_based_options_destroy(opts.c)
 	opts.c = nil
 	opts.cache = nil
-	opts.compCache = nil
+}
+
+// ToMap returns the effective value of all block-based table options as a
+// map of option names to values in the format understood by
+// GetBlockBasedTableOptionsFromString.
+func (opts *BlockBasedTableOptions) ToMap() (map[string]string, error) {
+	var cErr *C.char
+	cValue := C.gorocksdb_block_based_options_to_string(opts.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, errors.New(C.GoString(cErr))
+	}
+	defer C.free(unsafe.Pointer(cValue))
+	return parseOptionsString(C.GoString(cValue)), nil
 }
 
 // SetBlockSize sets the approximate size of user data packed per block.
@@ -65,15 +125,15 @@ func (opts *BlockBasedTableOptions) SetBlockRestartInterval(blockRestartInterval
 
 // SetFilterPolicy sets the filter policy opts reduce disk reads.
 // Many applications will benefit from passing the result of
-// NewBloomFilterPolicy() here.
+// NewBloomFilterPolicy() here. It panics if fp is not a native filter
+// policy, see FilterPolicy.
 // Default: nil
 func (opts *BlockBasedTableOptions) SetFilterPolicy(fp FilterPolicy) {
-	if nfp, ok := fp.(nativeFilterPolicy); ok {
-		opts.cFp = nfp.c
-	} else {
-		idx := registerFilterPolicy(fp)
-		opts.cFp = C.gorocksdb_filterpolicy_create(C.uintptr_t(idx))
+	nfp, ok := fp.(nativeFilterPolicy)
+	if !ok {
+		panic("gorocksdb: RocksDB does not support custom filter policies")
 	}
+	opts.cFp = nfp.c
 	C.rocksdb_block_based_options_set_filter_policy(opts.c, opts.cFp)
 }
 
@@ -97,15 +157,114 @@ func (opts *BlockBasedTableOptions) SetBlockCache(cache *Cache) {
 // SetBlockCacheCompressed sets the cache for compressed blocks.
 // If nil, rocksdb will not use a compressed block cache.
 // Default: nil
+//
+// Deprecated: RocksDB removed the compressed block cache, this does
+// nothing.
 func (opts *BlockBasedTableOptions) SetBlockCacheCompressed(cache *Cache) {
-	opts.compCache = cache
-	C.rocksdb_block_based_options_set_block_cache_compressed(opts.c, cache.c)
 }
 
 // SetWholeKeyFiltering specify if whole keys in the filter (not just prefixes)
 // should be placed.
 // This must generally be true for gets opts be efficient.
+// If a prefix extractor is set on the options, the prefixes of the keys
+// are added to the filter as well, which lets seeks within a prefix skip
+// files. Setting this to false then builds a prefix-only filter, which is
+// smaller but no longer helps point lookups of absent keys.
 // Default: true
 func (opts *BlockBasedTableOptions) SetWholeKeyFiltering(value bool) {
 	C.rocksdb_block_based_options_set_whole_key_filtering(opts.c, boolToChar(value))
 }
+
+// SetCacheIndexAndFilterBlocks is indicating if we'd put index/filter blocks
+// to the block cache. If not specified, each "table reader" object will
+// pre-load index/filter block during table initialization.
+// Default: false
+func (opts *BlockBasedTableOptions) SetCacheIndexAndFilterBlocks(value bool) {
+	C.rocksdb_block_based_options_set_cache_index_and_filter_blocks(opts.c, boolToChar(value))
+}
+
+// SetCacheIndexAndFilterBlocksWithHighPriority sets whether index and
+// filter blocks are put in the high priority pool of the block cache, so
+// that they are evicted after the data blocks. It only has an effect if
+// SetCacheIndexAndFilterBlocks is true and the block cache reserves a high
+// priority pool.
+// Default: true
+func (opts *BlockBasedTableOptions) SetCacheIndexAndFilterBlocksWithHighPriority(value bool) {
+	C.rocksdb_block_based_options_set_cache_index_and_filter_blocks_with_high_priority(opts.c, boolToChar(value))
+}
+
+// SetPinL0FilterAndIndexBlocksInCache sets whether the index and filter
+// blocks of level 0 files are pinned in the block cache and never evicted.
+// It only has an effect if SetCacheIndexAndFilterBlocks is true.
+// Default: false
+func (opts *BlockBasedTableOptions) SetPinL0FilterAndIndexBlocksInCache(value bool) {
+	C.rocksdb_block_based_options_set_pin_l0_filter_and_index_blocks_in_cache(opts.c, boolToChar(value))
+}
+
+// SetPinTopLevelIndexAndFilter sets whether the top level index of
+// partitioned indexes and filters is pinned in the block cache. It only has
+// an effect if SetCacheIndexAndFilterBlocks is true.
+// Default: true
+func (opts *BlockBasedTableOptions) SetPinTopLevelIndexAndFilter(value bool) {
+	C.rocksdb_block_based_options_set_pin_top_level_index_and_filter(opts.c, boolToChar(value))
+}
+
+// SetIndexType sets the index type used for the table files.
+// Default: BinarySearchIndexType
+func (opts *BlockBasedTableOptions) SetIndexType(value IndexType) {
+	C.rocksdb_block_based_options_set_index_type(opts.c, C.int(value))
+}
+
+// SetPartitionFilters sets whether the filter of a table file is split
+// into partitions like the index, so that only the partitions which are
+// needed are loaded into the block cache. It requires the
+// TwoLevelIndexSearchIndexType index type and a full filter policy.
+// Default: false
+func (opts *BlockBasedTableOptions) SetPartitionFilters(value bool) {
+	C.rocksdb_block_based_options_set_partition_filters(opts.c, boolToChar(value))
+}
+
+// SetMetadataBlockSize sets the target size of the partitions of
+// partitioned indexes and filters.
+// Default: 4K
+func (opts *BlockBasedTableOptions) SetMetadataBlockSize(value uint64) {
+	C.rocksdb_block_based_options_set_metadata_block_size(opts.c, C.uint64_t(value))
+}
+
+// SetFormatVersion sets the format version of new table files. Newer
+// versions compress and index data more efficiently, but cannot be read
+// by older RocksDB releases.
+// Default: 6
+func (opts *BlockBasedTableOptions) SetFormatVersion(value int) {
+	C.rocksdb_block_based_options_set_format_version(opts.c, C.int(value))
+}
+
+// SetDataBlockIndexType sets how keys are looked up within a data block.
+// Default: DataBlockBinarySearchIndexType
+func (opts *BlockBasedTableOptions) SetDataBlockIndexType(value DataBlockIndexType) {
+	C.rocksdb_block_based_options_set_data_block_index_type(opts.c, C.int(value))
+}
+
+// SetDataBlockHashRatio sets the utilization ratio of the hash index of
+// data blocks, the number of keys divided by the number of buckets. A
+// smaller ratio means fewer collisions but a larger index. It only has an
+// effect with the DataBlockBinaryAndHashIndexType data block index type.
+// Default: 0.75
+func (opts *BlockBasedTableOptions) SetDataBlockHashRatio(value float64) {
+	C.rocksdb_block_based_options_set_data_block_hash_ratio(opts.c, C.double(value))
+}
+
+// SetChecksum sets the checksum used to verify the blocks of new table
+// files.
+// Default: CRC32cChecksum
+func (opts *BlockBasedTableOptions) SetChecksum(value ChecksumType) {
+	C.rocksdb_block_based_options_set_checksum(opts.c, C.char(value))
+}
+
+// SetOptimizeFiltersForMemory sets whether the size of filters is chosen
+// to minimize the memory wasted by the allocator, at the cost of slightly
+// varying false positive rates.
+// Default: true
+func (opts *BlockBasedTableOptions) SetOptimizeFiltersForMemory(value bool) {
+	C.rocksdb_block_based_options_set_optimize_filters_for_memory(opts.c, boolToChar(value))
+}
@@ -0,0 +1,124 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+import "sync"
+
+// BottommostLevelCompaction specifies whether a manual compaction
+// compacts the bottommost level.
+type BottommostLevelCompaction uint
+
+// Bottommost level compaction modes.
+const (
+	// BottommostLevelCompactionSkip skips the bottommost level.
+	BottommostLevelCompactionSkip = BottommostLevelCompaction(0)
+	// BottommostLevelCompactionIfHaveCompactionFilter compacts the
+	// bottommost level only if a compaction filter is set.
+	BottommostLevelCompactionIfHaveCompactionFilter = BottommostLevelCompaction(1)
+	// BottommostLevelCompactionForce always compacts the bottommost level.
+	BottommostLevelCompactionForce = BottommostLevelCompaction(2)
+	// BottommostLevelCompactionForceOptimized always compacts the
+	// bottommost level but skips the files just created by this
+	// compaction.
+	BottommostLevelCompactionForceOptimized = BottommostLevelCompaction(3)
+)
+
+// CompactRangeOptions represent all of the available options for a manual
+// compaction with CompactRangeOpt.
+type CompactRangeOptions struct {
+	c *C.rocksdb_compactoptions_t
+
+	// Every compaction gets its own cancel flag, which is freed as soon as
+	// the compaction returns.
+	mu       sync.Mutex
+	running  map[*C.gorocksdb_cancel_flag_t]struct{}
+	inFlight sync.WaitGroup
+}
+
+// NewDefaultCompactRangeOptions creates a default CompactRangeOptions object.
+func NewDefaultCompactRangeOptions() *CompactRangeOptions {
+	return NewNativeCompactRangeOptions(C.rocksdb_compactoptions_create())
+}
+
+// NewNativeCompactRangeOptions creates a CompactRangeOptions object.
+func NewNativeCompactRangeOptions(c *C.rocksdb_compactoptions_t) *CompactRangeOptions {
+	return &CompactRangeOptions{c: c, running: make(map[*C.gorocksdb_cancel_flag_t]struct{})}
+}
+
+// SetExclusiveManualCompaction specifies whether the manual compaction
+// runs exclusively, i.e. no automatic compactions run at the same time.
+// Default: true
+func (opts *CompactRangeOptions) SetExclusiveManualCompaction(value bool) {
+	C.rocksdb_compactoptions_set_exclusive_manual_compaction(opts.c, boolToChar(value))
+}
+
+// SetChangeLevel specifies whether the compacted files are moved to the
+// level set with SetTargetLevel.
+// Default: false
+func (opts *CompactRangeOptions) SetChangeLevel(value bool) {
+	C.rocksdb_compactoptions_set_change_level(opts.c, boolToChar(value))
+}
+
+// SetTargetLevel sets the level the compacted files are moved to if
+// SetChangeLevel is true. If negative the files are moved to the minimum
+// level which can hold the data.
+// Default: -1
+func (opts *CompactRangeOptions) SetTargetLevel(value int) {
+	C.rocksdb_compactoptions_set_target_level(opts.c, C.int(value))
+}
+
+// SetBottommostLevelCompaction sets whether the bottommost level is
+// compacted.
+// Default: BottommostLevelCompactionIfHaveCompactionFilter
+func (opts *CompactRangeOptions) SetBottommostLevelCompaction(value BottommostLevelCompaction) {
+	C.rocksdb_compactoptions_set_bottommost_level_compaction(opts.c, C.uchar(value))
+}
+
+// SetMaxSubcompactions sets the maximum number of threads the manual
+// compaction is split into. If 0 the max_subcompactions of the database
+// options is used.
+// Default: 0
+func (opts *CompactRangeOptions) SetMaxSubcompactions(value int) {
+	C.rocksdb_compactoptions_set_max_subcompactions(opts.c, C.int(value))
+}
+
+// Cancel cancels the manual compactions currently running with these
+// options. They stop as soon as possible and return an error. Compactions
+// started after Cancel returns are not affected.
+func (opts *CompactRangeOptions) Cancel() {
+	opts.mu.Lock()
+	defer opts.mu.Unlock()
+	for flag := range opts.running {
+		C.gorocksdb_cancel_flag_set(flag)
+	}
+}
+
+// start creates the cancel flag of a compaction about to run with the
+// options.
+func (opts *CompactRangeOptions) start() *C.gorocksdb_cancel_flag_t {
+	flag := C.gorocksdb_cancel_flag_create()
+	opts.mu.Lock()
+	opts.running[flag] = struct{}{}
+	opts.inFlight.Add(1)
+	opts.mu.Unlock()
+	return flag
+}
+
+// finish frees the cancel flag of a compaction which has returned.
+func (opts *CompactRangeOptions) finish(flag *C.gorocksdb_cancel_flag_t) {
+	opts.mu.Lock()
+	delete(opts.running, flag)
+	opts.mu.Unlock()
+	C.gorocksdb_cancel_flag_destroy(flag)
+	opts.inFlight.Done()
+}
+
+// Destroy deallocates the CompactRangeOptions object. It waits for the
+// compactions running with the options to return, call Cancel first to
+// make them stop early.
+func (opts *CompactRangeOptions) Destroy() {
+	opts.inFlight.Wait()
+	C.rocksdb_compactoptions_destroy(opts.c)
+	opts.c = nil
+}
@@ -1,10 +1,38 @@
 package gorocksdb
 
+import (
+	"fmt"
+	"sync"
+)
+
 // CompressionOptions represents options for different compression algorithms like Zlib.
 type CompressionOptions struct {
 	WindowBits int
 	Level      int
 	Strategy   int
+
+	// MaxDictBytes is the maximum size of the dictionary trained from the
+	// data of each bottommost output file of a compaction. The dictionary
+	// improves the compression of small blocks. 0 disables dictionary
+	// compression.
+	MaxDictBytes int
+
+	// ZstdMaxTrainBytes is the maximum amount of sampled data passed to the
+	// ZSTD dictionary trainer. If the limit is 0, the samples are used as
+	// the dictionary directly. Setting the field to 0 keeps the value
+	// currently in the options, which defaults to 0.
+	ZstdMaxTrainBytes int
+
+	// UseZstdDictTrainer selects the ZSTD dictionary trainer. If false the
+	// faster ZSTD finalizer is used to build the dictionary. nil keeps the
+	// RocksDB default, which is to use the trainer.
+	UseZstdDictTrainer *bool
+
+	// MaxDictBufferBytes limits the data buffered per output file to
+	// sample the dictionary from. A limit of 0 means no limit. Setting the
+	// field to 0 keeps the value currently in the options, which defaults
+	// to 0.
+	MaxDictBufferBytes uint64
 }
 
 // NewDefaultCompressionOptions creates a default CompressionOptions object.
@@ -20,3 +48,75 @@ func NewCompressionOptions(windowBits, level, strategy int) *CompressionOptions
 		Strategy:   strategy,
 	}
 }
+
+// String returns the name of the compression type.
+func (t CompressionType) String() string {
+	switch t {
+	case NoCompression:
+		return "NoCompression"
+	case SnappyCompression:
+		return "Snappy"
+	case ZLibCompression:
+		return "ZLib"
+	case Bz2Compression:
+		return "BZip2"
+	case LZ4Compression:
+		return "LZ4"
+	case LZ4HCCompression:
+		return "LZ4HC"
+	case XpressCompression:
+		return "Xpress"
+	case ZSTDCompression:
+		return "ZSTD"
+	case DisableCompressionOption:
+		return "DisableOption"
+	}
+	return fmt.Sprintf("CompressionType(%d)", uint(t))
+}
+
+var (
+	supportedCompressionsOnce sync.Once
+	supportedCompressions     []CompressionType
+)
+
+// SupportedCompressions returns the compression types the linked RocksDB
+// library was built with. Opening a database with any other compression
+// fails.
+func SupportedCompressions() []CompressionType {
+	supportedCompressionsOnce.Do(func() {
+		supportedCompressions = probeCompressions()
+	})
+	return append([]CompressionType(nil), supportedCompressions...)
+}
+
+// probeCompressions opens a database in memory for each compression type,
+// since RocksDB only reports unsupported compressions when validating the
+// options of a database.
+func probeCompressions() []CompressionType {
+	env := NewMemEnv()
+	defer env.Destroy()
+	types := []CompressionType{
+		NoCompression,
+		SnappyCompression,
+		ZLibCompression,
+		Bz2Compression,
+		LZ4Compression,
+		LZ4HCCompression,
+		XpressCompression,
+		ZSTDCompression,
+	}
+	var supported []CompressionType
+	for _, t := range types {
+		opts := NewDefaultOptions()
+		opts.SetEnv(env)
+		opts.SetCreateIfMissing(true)
+		opts.SetCompression(t)
+		db, err := OpenDb(opts, fmt.Sprintf("/gorocksdb-compression-%d", uint(t)))
+		if err == nil {
+			supported = append(supported, t)
+			db.Close()
+		}
+		opts.Destroy()
+	}
+	return supported
+}
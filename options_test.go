@@ -0,0 +1,176 @@
+package gorocksdb
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestOptionsGetters(t *testing.T) {
+	opts := NewDefaultOptions()
+	defer opts.Destroy()
+
+	opts.SetCreateIfMissing(true)
+	opts.SetWriteBufferSize(8 << 20)
+	opts.SetMaxWriteBufferNumber(5)
+	opts.SetCompactionStyle(UniversalCompactionStyle)
+	opts.SetDisableAutoCompactions(true)
+	opts.SetInfoLogLevel(WarnInfoLogLevel)
+	opts.SetMaxBytesForLevelMultiplier(8.5)
+
+	ensure.True(t, opts.GetCreateIfMissing())
+	ensure.DeepEqual(t, opts.GetWriteBufferSize(), 8<<20)
+	ensure.DeepEqual(t, opts.GetMaxWriteBufferNumber(), 5)
+	ensure.DeepEqual(t, opts.GetCompactionStyle(), UniversalCompactionStyle)
+	ensure.True(t, opts.GetDisableAutoCompactions())
+	ensure.DeepEqual(t, opts.GetInfoLogLevel(), WarnInfoLogLevel)
+	ensure.DeepEqual(t, opts.GetMaxBytesForLevelMultiplier(), 8.5)
+	ensure.DeepEqual(t, opts.GetComparatorName(), "leveldb.BytewiseComparator")
+
+	opts.SetComparator(&bytesReverseComparator{})
+	ensure.DeepEqual(t, opts.GetComparatorName(), "gorocksdb.bytes-reverse")
+}
+
+func TestOptionsToMap(t *testing.T) {
+	bbto := NewDefaultBlockBasedTableOptions()
+	defer bbto.Destroy()
+	bbto.SetBlockSize(16384)
+	bm, err := bbto.ToMap()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, bm["block_size"], "16384")
+
+	opts := NewDefaultOptions()
+	defer opts.Destroy()
+	opts.SetWriteBufferSize(8 << 20)
+	opts.SetCreateIfMissing(true)
+	opts.SetBlockBasedTableFactory(bbto)
+
+	m, err := opts.ToMap()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, m["write_buffer_size"], "8388608")
+	ensure.DeepEqual(t, m["create_if_missing"], "true")
+	ensure.DeepEqual(t, parseOptionsString(m["block_based_table_factory"])["block_size"], "16384")
+
+	// the map can be used to recreate the options
+	newOpts, err := GetOptionsFromMap(nil, m)
+	ensure.Nil(t, err)
+	defer newOpts.Destroy()
+	ensure.DeepEqual(t, newOpts.GetWriteBufferSize(), 8<<20)
+}
+
+func TestParseOptionsString(t *testing.T) {
+	m := parseOptionsString("a=1;b={c=2;d={e=3}};f=;g=x y")
+	ensure.DeepEqual(t, m, map[string]string{
+		"a": "1",
+		"b": "c=2;d={e=3}",
+		"f": "",
+		"g": "x y",
+	})
+}
+
+func TestBlockBasedTableOptionsToMap(t *testing.T) {
+	bbto := NewDefaultBlockBasedTableOptions()
+	defer bbto.Destroy()
+	bbto.SetCacheIndexAndFilterBlocks(true)
+	bbto.SetCacheIndexAndFilterBlocksWithHighPriority(true)
+	bbto.SetPinL0FilterAndIndexBlocksInCache(true)
+	bbto.SetIndexType(TwoLevelIndexSearchIndexType)
+	bbto.SetPartitionFilters(true)
+	bbto.SetMetadataBlockSize(8192)
+	bbto.SetFormatVersion(5)
+	bbto.SetDataBlockIndexType(DataBlockBinaryAndHashIndexType)
+	bbto.SetDataBlockHashRatio(0.5)
+	bbto.SetChecksum(XXH3Checksum)
+	bbto.SetOptimizeFiltersForMemory(false)
+	bbto.SetWholeKeyFiltering(false)
+
+	m, err := bbto.ToMap()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, m["cache_index_and_filter_blocks"], "true")
+	ensure.DeepEqual(t, m["cache_index_and_filter_blocks_with_high_priority"], "true")
+	ensure.DeepEqual(t, m["pin_l0_filter_and_index_blocks_in_cache"], "true")
+	ensure.DeepEqual(t, m["index_type"], "kTwoLevelIndexSearch")
+	ensure.DeepEqual(t, m["partition_filters"], "true")
+	ensure.DeepEqual(t, m["metadata_block_size"], "8192")
+	ensure.DeepEqual(t, m["format_version"], "5")
+	ensure.DeepEqual(t, m["data_block_index_type"], "kDataBlockBinaryAndHash")
+	ensure.DeepEqual(t, m["data_block_hash_table_util_ratio"], "0.500000")
+	ensure.DeepEqual(t, m["checksum"], "kXXH3")
+	ensure.DeepEqual(t, m["optimize_filters_for_memory"], "false")
+	ensure.DeepEqual(t, m["whole_key_filtering"], "false")
+}
+
+func TestCompressionOptions(t *testing.T) {
+	opts := NewDefaultOptions()
+	defer opts.Destroy()
+	opts.SetCompression(LZ4Compression)
+	opts.SetBottommostCompression(ZSTDCompression)
+	ensure.DeepEqual(t, opts.GetCompression(), LZ4Compression)
+	ensure.DeepEqual(t, opts.GetBottommostCompression(), ZSTDCompression)
+
+	co := NewDefaultCompressionOptions()
+	co.MaxDictBytes = 16 << 10
+	co.ZstdMaxTrainBytes = 100 << 10
+	opts.SetCompressionOptions(co)
+	bco := NewDefaultCompressionOptions()
+	bco.Level = 19
+	bco.MaxDictBytes = 64 << 10
+	opts.SetBottommostCompressionOptions(bco)
+
+	m, err := opts.ToMap()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, m["compression"], "kLZ4Compression")
+	ensure.DeepEqual(t, m["bottommost_compression"], "kZSTD")
+	cm := parseOptionsString(m["compression_opts"])
+	ensure.DeepEqual(t, cm["max_dict_bytes"], "16384")
+	ensure.DeepEqual(t, cm["zstd_max_train_bytes"], "102400")
+	bm := parseOptionsString(m["bottommost_compression_opts"])
+	ensure.DeepEqual(t, bm["enabled"], "true")
+	ensure.DeepEqual(t, bm["level"], "19")
+	ensure.DeepEqual(t, bm["max_dict_bytes"], "65536")
+
+	// fields left unset keep the RocksDB defaults
+	opts.SetCompressionOptions(&CompressionOptions{WindowBits: -14, Level: -1, MaxDictBytes: 16 << 10})
+	m, err = opts.ToMap()
+	ensure.Nil(t, err)
+	cm = parseOptionsString(m["compression_opts"])
+	ensure.DeepEqual(t, cm["use_zstd_dict_trainer"], "true")
+	ensure.DeepEqual(t, cm["zstd_max_train_bytes"], "102400")
+
+	// zero values don't overwrite the values set before
+	co.MaxDictBufferBytes = 1 << 20
+	opts.SetCompressionOptions(co)
+	bco.ZstdMaxTrainBytes = 256 << 10
+	bco.MaxDictBufferBytes = 4 << 20
+	opts.SetBottommostCompressionOptions(bco)
+	opts.SetCompressionOptions(&CompressionOptions{WindowBits: -14, Level: -1, MaxDictBytes: 16 << 10})
+	opts.SetBottommostCompressionOptions(&CompressionOptions{WindowBits: -14, Level: 19, MaxDictBytes: 64 << 10})
+	m, err = opts.ToMap()
+	ensure.Nil(t, err)
+	cm = parseOptionsString(m["compression_opts"])
+	ensure.DeepEqual(t, cm["zstd_max_train_bytes"], "102400")
+	ensure.DeepEqual(t, cm["max_dict_buffer_bytes"], "1048576")
+	bm = parseOptionsString(m["bottommost_compression_opts"])
+	ensure.DeepEqual(t, bm["zstd_max_train_bytes"], "262144")
+	ensure.DeepEqual(t, bm["max_dict_buffer_bytes"], "4194304")
+
+	useTrainer := false
+	opts.SetCompressionOptions(&CompressionOptions{WindowBits: -14, Level: -1, UseZstdDictTrainer: &useTrainer})
+	m, err = opts.ToMap()
+	ensure.Nil(t, err)
+	cm = parseOptionsString(m["compression_opts"])
+	ensure.DeepEqual(t, cm["use_zstd_dict_trainer"], "false")
+}
+
+func TestSupportedCompressions(t *testing.T) {
+	supported := SupportedCompressions()
+	ensure.True(t, len(supported) > 0)
+	ensure.DeepEqual(t, supported[0], NoCompression)
+
+	for _, c := range supported {
+		db := newTestDB(t, "TestSupportedCompressions"+c.String(), func(opts *Options) {
+			opts.SetCompression(c)
+		})
+		db.Close()
+	}
+}
@@ -0,0 +1,179 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+// GetOptionsFromString creates a new Options object from base with the
+// options given in the string applied on top of it, e.g.
+// "write_buffer_size=1048576;max_write_buffer_number=4". Nested options are
+// enclosed in braces, e.g. "block_based_table_factory={block_size=4096}".
+//
+// If base is nil the default options are used. Comparators and compaction
+// filters set on base are shared with the returned Options, so base must not
+// be destroyed while the returned Options is in use.
+func GetOptionsFromString(base *Options, optStr string) (*Options, error) {
+	if base == nil {
+		base = NewDefaultOptions()
+		defer base.Destroy()
+	}
+	var (
+		cErr    *C.char
+		cOptStr = C.CString(optStr)
+	)
+	defer C.free(unsafe.Pointer(cOptStr))
+
+	newOpts := NewDefaultOptions()
+	C.rocksdb_get_options_from_string(base.c, cOptStr, newOpts.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		newOpts.Destroy()
+		return nil, errors.New(C.GoString(cErr))
+	}
+	newOpts.env = base.env
+	newOpts.bbto = base.bbto
+	return newOpts, nil
+}
+
+// GetOptionsFromMap is like GetOptionsFromString but takes the options as a
+// map of option names to values, e.g. {"write_buffer_size": "1048576"}.
+func GetOptionsFromMap(base *Options, opts map[string]string) (*Options, error) {
+	return GetOptionsFromString(base, optionsMapToString(opts))
+}
+
+// GetBlockBasedTableOptionsFromString creates a new BlockBasedTableOptions
+// object from base with the options given in the string applied on top of
+// it, e.g. "block_size=16384;cache_index_and_filter_blocks=true".
+//
+// If base is nil the default block based table options are used.
+func GetBlockBasedTableOptionsFromString(base *BlockBasedTableOptions, optStr string) (*BlockBasedTableOptions, error) {
+	if base == nil {
+		base = NewDefaultBlockBasedTableOptions()
+		defer base.Destroy()
+	}
+	var (
+		cErr    *C.char
+		cOptStr = C.CString(optStr)
+	)
+	defer C.free(unsafe.Pointer(cOptStr))
+
+	newOpts := NewDefaultBlockBasedTableOptions()
+	C.gorocksdb_get_block_based_table_options_from_string(base.c, cOptStr, newOpts.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		newOpts.Destroy()
+		return nil, errors.New(C.GoString(cErr))
+	}
+	newOpts.cache = base.cache
+	return newOpts, nil
+}
+
+// LoadLatestOptions loads the options from the latest OPTIONS file RocksDB
+// wrote into the database directory. It returns the database options and
+// the names and options of all column families, which can be passed to
+// OpenDbColumnFamilies.
+//
+// Comparators, merge operators and other callbacks are not persisted and
+// have to be set on the returned options again before opening the database.
+func LoadLatestOptions(dbPath string) (*Options, []string, []*Options, error) {
+	var (
+		cErr    *C.char
+		cNumCFs C.size_t
+		cNames  **C.char
+		cOpts   **C.rocksdb_options_t
+		cPath   = C.CString(dbPath)
+	)
+	defer C.free(unsafe.Pointer(cPath))
+
+	dbOpts := NewDefaultOptions()
+	C.gorocksdb_load_latest_options(cPath, dbOpts.c, &cNumCFs, &cNames, &cOpts, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		dbOpts.Destroy()
+		return nil, nil, nil, errors.New(C.GoString(cErr))
+	}
+	defer C.free(unsafe.Pointer(cNames))
+	defer C.free(unsafe.Pointer(cOpts))
+
+	numCFs := int(cNumCFs)
+	cNamesArr := (*[1 << 30]*C.char)(unsafe.Pointer(cNames))[:numCFs:numCFs]
+	cOptsArr := (*[1 << 30]*C.rocksdb_options_t)(unsafe.Pointer(cOpts))[:numCFs:numCFs]
+	cfNames := make([]string, numCFs)
+	cfOpts := make([]*Options, numCFs)
+	for i := 0; i < numCFs; i++ {
+		cfNames[i] = C.GoString(cNamesArr[i])
+		C.free(unsafe.Pointer(cNamesArr[i]))
+		cfOpts[i] = NewNativeOptions(cOptsArr[i])
+	}
+	return dbOpts, cfNames, cfOpts, nil
+}
+
+// optionsMapToString joins the options into the "name=value;..." format
+// understood by GetOptionsFromString. Values containing separators are
+// enclosed in braces.
+func optionsMapToString(opts map[string]string) string {
+	names := make([]string, 0, len(opts))
+	for name := range opts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		value := opts[name]
+		if strings.ContainsAny(value, ";=") && !strings.HasPrefix(value, "{") {
+			value = "{" + value + "}"
+		}
+		parts[i] = name + "=" + value
+	}
+	return strings.Join(parts, ";")
+}
+
+// parseOptionsString splits an option string in the "name=value;..." format
+// written by RocksDB into a map. Braces around nested values are removed.
+func parseOptionsString(s string) map[string]string {
+	opts := make(map[string]string)
+	for len(s) > 0 {
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			break
+		}
+		name := strings.TrimSpace(strings.TrimLeft(s[:eq], ";"))
+		s = strings.TrimLeft(s[eq+1:], " ")
+
+		var value string
+		if strings.HasPrefix(s, "{") {
+			depth, end := 0, len(s)
+			for i := 0; i < len(s) && end == len(s); i++ {
+				switch s[i] {
+				case '{':
+					depth++
+				case '}':
+					depth--
+					if depth == 0 {
+						end = i
+					}
+				}
+			}
+			value = s[1:end]
+			if end < len(s) {
+				end++
+			}
+			s = s[end:]
+		} else if semi := strings.IndexByte(s, ';'); semi >= 0 {
+			value, s = s[:semi], s[semi:]
+		} else {
+			value, s = s, ""
+		}
+		opts[name] = strings.TrimSpace(value)
+		s = strings.TrimLeft(s, "; ")
+	}
+	return opts
+}
@@ -0,0 +1,91 @@
+package gorocksdb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestGetOptionsFromString(t *testing.T) {
+	opts, err := GetOptionsFromString(nil, "write_buffer_size=1048576;max_write_buffer_number=4")
+	ensure.Nil(t, err)
+	defer opts.Destroy()
+	ensure.DeepEqual(t, opts.GetWriteBufferSize(), 1048576)
+	ensure.DeepEqual(t, opts.GetMaxWriteBufferNumber(), 4)
+
+	_, err = GetOptionsFromString(nil, "not_an_option=1")
+	ensure.NotNil(t, err)
+}
+
+func TestGetOptionsFromMap(t *testing.T) {
+	base := NewDefaultOptions()
+	defer base.Destroy()
+	opts, err := GetOptionsFromMap(base, map[string]string{
+		"write_buffer_size":         "1048576",
+		"block_based_table_factory": "block_size=16384;whole_key_filtering=false",
+	})
+	ensure.Nil(t, err)
+	defer opts.Destroy()
+	ensure.DeepEqual(t, opts.GetWriteBufferSize(), 1048576)
+
+	// the nested table options are only applied if they were escaped
+	m, err := opts.ToMap()
+	ensure.Nil(t, err)
+	tm := parseOptionsString(m["block_based_table_factory"])
+	ensure.DeepEqual(t, tm["block_size"], "16384")
+	ensure.DeepEqual(t, tm["whole_key_filtering"], "false")
+}
+
+func TestGetBlockBasedTableOptionsFromString(t *testing.T) {
+	bbto, err := GetBlockBasedTableOptionsFromString(nil, "block_size=16384;block_restart_interval=8")
+	ensure.Nil(t, err)
+	defer bbto.Destroy()
+	m, err := bbto.ToMap()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, m["block_size"], "16384")
+	ensure.DeepEqual(t, m["block_restart_interval"], "8")
+
+	_, err = GetBlockBasedTableOptionsFromString(nil, "block_size=abc")
+	ensure.NotNil(t, err)
+}
+
+func TestLoadLatestOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestLoadLatestOptions")
+	ensure.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	givenNames := []string{"default", "guide"}
+	opts := NewDefaultOptions()
+	defer opts.Destroy()
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.SetCreateIfMissing(true)
+	opts.SetKeepLogFileNum(7)
+	guideOpts := NewDefaultOptions()
+	defer guideOpts.Destroy()
+	guideOpts.SetWriteBufferSize(1 << 20)
+	db, _, err := OpenDbColumnFamilies(opts, dir, givenNames, []*Options{opts, guideOpts})
+	ensure.Nil(t, err)
+	db.Close()
+
+	dbOpts, cfNames, cfOpts, err := LoadLatestOptions(dir)
+	ensure.Nil(t, err)
+	defer dbOpts.Destroy()
+	defer func() {
+		for _, o := range cfOpts {
+			o.Destroy()
+		}
+	}()
+	ensure.DeepEqual(t, cfNames, givenNames)
+	ensure.DeepEqual(t, len(cfOpts), 2)
+
+	// the values which differ from the defaults are loaded
+	ensure.DeepEqual(t, dbOpts.GetKeepLogFileNum(), 7)
+	ensure.DeepEqual(t, cfOpts[0].GetWriteBufferSize(), opts.GetWriteBufferSize())
+	ensure.DeepEqual(t, cfOpts[1].GetWriteBufferSize(), 1<<20)
+
+	db, _, err = OpenDbColumnFamilies(dbOpts, dir, cfNames, cfOpts)
+	ensure.Nil(t, err)
+	db.Close()
+}
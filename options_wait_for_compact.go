@@ -0,0 +1,50 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+import "C"
+import "time"
+
+// WaitForCompactOptions represent all of the available options for
+// WaitForCompact.
+type WaitForCompactOptions struct {
+	c *C.rocksdb_wait_for_compact_options_t
+}
+
+// NewDefaultWaitForCompactOptions creates a default WaitForCompactOptions
+// object.
+func NewDefaultWaitForCompactOptions() *WaitForCompactOptions {
+	return NewNativeWaitForCompactOptions(C.rocksdb_wait_for_compact_options_create())
+}
+
+// NewNativeWaitForCompactOptions creates a WaitForCompactOptions object.
+func NewNativeWaitForCompactOptions(c *C.rocksdb_wait_for_compact_options_t) *WaitForCompactOptions {
+	return &WaitForCompactOptions{c}
+}
+
+// SetAbortOnPause specifies whether WaitForCompact returns an error right
+// away if background work is paused, instead of waiting until it is
+// continued.
+// Default: false
+func (opts *WaitForCompactOptions) SetAbortOnPause(value bool) {
+	C.rocksdb_wait_for_compact_options_set_abort_on_pause(opts.c, boolToChar(value))
+}
+
+// SetFlush specifies whether the memtables are flushed before waiting for
+// the compactions.
+// Default: false
+func (opts *WaitForCompactOptions) SetFlush(value bool) {
+	C.rocksdb_wait_for_compact_options_set_flush(opts.c, boolToChar(value))
+}
+
+// SetTimeout sets how long WaitForCompact waits before it returns a timed
+// out error. Zero means no timeout.
+// Default: 0
+func (opts *WaitForCompactOptions) SetTimeout(value time.Duration) {
+	C.rocksdb_wait_for_compact_options_set_timeout(opts.c, C.uint64_t(value/time.Microsecond))
+}
+
+// Destroy deallocates the WaitForCompactOptions object.
+func (opts *WaitForCompactOptions) Destroy() {
+	C.rocksdb_wait_for_compact_options_destroy(opts.c)
+	opts.c = nil
+}
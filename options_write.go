@@ -35,6 +35,21 @@ func (opts *WriteOptions) DisableWAL(value bool) {
 	C.rocksdb_writeoptions_disable_WAL(opts.c, C.int(btoi(value)))
 }
 
+// SetNoSlowdown specifies whether a write fails with an Incomplete error
+// instead of waiting if it would be delayed or stopped by a write stall.
+// Default: false
+func (opts *WriteOptions) SetNoSlowdown(value bool) {
+	C.rocksdb_writeoptions_set_no_slowdown(opts.c, boolToChar(value))
+}
+
+// SetLowPri marks the writes as low priority. They are slowed down, or
+// fail if SetNoSlowdown is true, when compactions fall behind, so that
+// high priority writes are stalled less.
+// Default: false
+func (opts *WriteOptions) SetLowPri(value bool) {
+	C.rocksdb_writeoptions_set_low_pri(opts.c, boolToChar(value))
+}
+
 // Destroy deallocates the WriteOptions object.
 func (opts *WriteOptions) Destroy() {
 	C.rocksdb_writeoptions_destroy(opts.c)
@@ -0,0 +1,170 @@
+package gorocksdb
+
+// Names of the database properties which can be passed to GetProperty,
+// GetIntProperty and GetMapProperty and their column family variants.
+const (
+	// PropertyNumFilesAtLevelPrefix followed by a level number, e.g.
+	// "rocksdb.num-files-at-level0", is the number of files at that level.
+	PropertyNumFilesAtLevelPrefix = "rocksdb.num-files-at-level"
+	// PropertyCompressionRatioAtLevelPrefix followed by a level number is
+	// the compression ratio of the data at that level.
+	PropertyCompressionRatioAtLevelPrefix = "rocksdb.compression-ratio-at-level"
+	// PropertyStats is a multi-line text of the column family and DB stats.
+	PropertyStats = "rocksdb.stats"
+	// PropertySSTables is a multi-line text summarizing the current SST files.
+	PropertySSTables = "rocksdb.sstables"
+	// PropertyCFStats is the column family stats, also available as a map.
+	PropertyCFStats = "rocksdb.cfstats"
+	// PropertyCFStatsNoFileHistogram is PropertyCFStats without the file
+	// read latency histogram.
+	PropertyCFStatsNoFileHistogram = "rocksdb.cfstats-no-file-histogram"
+	// PropertyCFFileHistogram is the file read latency histogram per level.
+	PropertyCFFileHistogram = "rocksdb.cf-file-histogram"
+	// PropertyCFWriteStallStats is the column family write stall stats,
+	// also available as a map.
+	PropertyCFWriteStallStats = "rocksdb.cf-write-stall-stats"
+	// PropertyDBWriteStallStats is the DB wide write stall stats, also
+	// available as a map.
+	PropertyDBWriteStallStats = "rocksdb.db-write-stall-stats"
+	// PropertyDBStats is the DB wide stats, also available as a map.
+	PropertyDBStats = "rocksdb.dbstats"
+	// PropertyLevelStats is a multi-line text with the number of files and
+	// the total size per level.
+	PropertyLevelStats = "rocksdb.levelstats"
+	// PropertyBlockCacheEntryStats is the block cache usage per entry role,
+	// also available as a map.
+	PropertyBlockCacheEntryStats = "rocksdb.block-cache-entry-stats"
+	// PropertyFastBlockCacheEntryStats is like PropertyBlockCacheEntryStats
+	// but may return stale values.
+	PropertyFastBlockCacheEntryStats = "rocksdb.fast-block-cache-entry-stats"
+	// PropertyNumImmutableMemTable is the number of immutable memtables
+	// which have not yet been flushed.
+	PropertyNumImmutableMemTable = "rocksdb.num-immutable-mem-table"
+	// PropertyNumImmutableMemTableFlushed is the number of immutable
+	// memtables which have already been flushed.
+	PropertyNumImmutableMemTableFlushed = "rocksdb.num-immutable-mem-table-flushed"
+	// PropertyMemTableFlushPending is 1 if a memtable flush is pending.
+	PropertyMemTableFlushPending = "rocksdb.mem-table-flush-pending"
+	// PropertyNumRunningFlushes is the number of currently running flushes.
+	PropertyNumRunningFlushes = "rocksdb.num-running-flushes"
+	// PropertyCompactionPending is 1 if at least one compaction is pending.
+	PropertyCompactionPending = "rocksdb.compaction-pending"
+	// PropertyNumRunningCompactions is the number of currently running
+	// compactions.
+	PropertyNumRunningCompactions = "rocksdb.num-running-compactions"
+	// PropertyBackgroundErrors is the accumulated number of background errors.
+	PropertyBackgroundErrors = "rocksdb.background-errors"
+	// PropertyCurSizeActiveMemTable is the approximate size of the active
+	// memtable in bytes.
+	PropertyCurSizeActiveMemTable = "rocksdb.cur-size-active-mem-table"
+	// PropertyCurSizeAllMemTables is the approximate size of the active and
+	// unflushed immutable memtables in bytes.
+	PropertyCurSizeAllMemTables = "rocksdb.cur-size-all-mem-tables"
+	// PropertySizeAllMemTables is the approximate size of the active,
+	// unflushed immutable and pinned immutable memtables in bytes.
+	PropertySizeAllMemTables = "rocksdb.size-all-mem-tables"
+	// PropertyNumEntriesActiveMemTable is the number of entries in the
+	// active memtable.
+	PropertyNumEntriesActiveMemTable = "rocksdb.num-entries-active-mem-table"
+	// PropertyNumEntriesImmMemTables is the number of entries in the
+	// unflushed immutable memtables.
+	PropertyNumEntriesImmMemTables = "rocksdb.num-entries-imm-mem-tables"
+	// PropertyNumDeletesActiveMemTable is the number of delete entries in
+	// the active memtable.
+	PropertyNumDeletesActiveMemTable = "rocksdb.num-deletes-active-mem-table"
+	// PropertyNumDeletesImmMemTables is the number of delete entries in the
+	// unflushed immutable memtables.
+	PropertyNumDeletesImmMemTables = "rocksdb.num-deletes-imm-mem-tables"
+	// PropertyEstimateNumKeys is the estimated number of keys.
+	PropertyEstimateNumKeys = "rocksdb.estimate-num-keys"
+	// PropertyEstimateTableReadersMem is the estimated memory used by table
+	// readers, not including memory used by the block cache.
+	PropertyEstimateTableReadersMem = "rocksdb.estimate-table-readers-mem"
+	// PropertyIsFileDeletionsEnabled is 0 if file deletions are disabled.
+	PropertyIsFileDeletionsEnabled = "rocksdb.is-file-deletions-enabled"
+	// PropertyNumSnapshots is the number of unreleased snapshots.
+	PropertyNumSnapshots = "rocksdb.num-snapshots"
+	// PropertyOldestSnapshotTime is the unix time of the oldest unreleased
+	// snapshot.
+	PropertyOldestSnapshotTime = "rocksdb.oldest-snapshot-time"
+	// PropertyOldestSnapshotSequence is the sequence number of the oldest
+	// unreleased snapshot.
+	PropertyOldestSnapshotSequence = "rocksdb.oldest-snapshot-sequence"
+	// PropertyNumLiveVersions is the number of live versions.
+	PropertyNumLiveVersions = "rocksdb.num-live-versions"
+	// PropertyCurrentSuperVersionNumber is the number of the current
+	// super version, which changes whenever the LSM tree changes.
+	PropertyCurrentSuperVersionNumber = "rocksdb.current-super-version-number"
+	// PropertyEstimateLiveDataSize is the estimated amount of live data in
+	// bytes.
+	PropertyEstimateLiveDataSize = "rocksdb.estimate-live-data-size"
+	// PropertyMinLogNumberToKeep is the minimum log number of the WAL files
+	// which must be kept.
+	PropertyMinLogNumberToKeep = "rocksdb.min-log-number-to-keep"
+	// PropertyMinObsoleteSstNumberToKeep is the minimum file number of the
+	// obsolete SST files which must be kept.
+	PropertyMinObsoleteSstNumberToKeep = "rocksdb.min-obsolete-sst-number-to-keep"
+	// PropertyTotalSstFilesSize is the total size of all SST files in bytes.
+	PropertyTotalSstFilesSize = "rocksdb.total-sst-files-size"
+	// PropertyLiveSstFilesSize is the total size of the SST files belonging
+	// to the latest LSM tree in bytes.
+	PropertyLiveSstFilesSize = "rocksdb.live-sst-files-size"
+	// PropertyObsoleteSstFilesSize is the total size of the SST files which
+	// are obsolete but not yet deleted in bytes.
+	PropertyObsoleteSstFilesSize = "rocksdb.obsolete-sst-files-size"
+	// PropertyLiveSstFilesSizeAtTemperature is the total size of the live
+	// SST files per temperature.
+	PropertyLiveSstFilesSizeAtTemperature = "rocksdb.live-sst-files-size-at-temperature"
+	// PropertyBaseLevel is the level to which L0 data is compacted.
+	PropertyBaseLevel = "rocksdb.base-level"
+	// PropertyEstimatePendingCompactionBytes is the estimated number of
+	// bytes compaction needs to rewrite to get all levels down to their
+	// target size.
+	PropertyEstimatePendingCompactionBytes = "rocksdb.estimate-pending-compaction-bytes"
+	// PropertyAggregatedTableProperties is the aggregated table properties
+	// of the column family, also available as a map.
+	PropertyAggregatedTableProperties = "rocksdb.aggregated-table-properties"
+	// PropertyAggregatedTablePropertiesAtLevel followed by a level number
+	// is the aggregated table properties of that level.
+	PropertyAggregatedTablePropertiesAtLevel = "rocksdb.aggregated-table-properties-at-level"
+	// PropertyActualDelayedWriteRate is the current write rate in bytes per
+	// second if writes are delayed, 0 otherwise.
+	PropertyActualDelayedWriteRate = "rocksdb.actual-delayed-write-rate"
+	// PropertyIsWriteStopped is 1 if writes are stopped.
+	PropertyIsWriteStopped = "rocksdb.is-write-stopped"
+	// PropertyEstimateOldestKeyTime is the estimated unix time of the
+	// oldest key. Only available for FIFO compaction.
+	PropertyEstimateOldestKeyTime = "rocksdb.estimate-oldest-key-time"
+	// PropertyBlockCacheCapacity is the capacity of the block cache.
+	PropertyBlockCacheCapacity = "rocksdb.block-cache-capacity"
+	// PropertyBlockCacheUsage is the memory size of the entries residing in
+	// the block cache.
+	PropertyBlockCacheUsage = "rocksdb.block-cache-usage"
+	// PropertyBlockCachePinnedUsage is the memory size of the entries
+	// pinned in the block cache.
+	PropertyBlockCachePinnedUsage = "rocksdb.block-cache-pinned-usage"
+	// PropertyOptionsStatistics is a multi-line text of the statistics
+	// collected when statistics are enabled.
+	PropertyOptionsStatistics = "rocksdb.options-statistics"
+	// PropertyNumBlobFiles is the number of blob files.
+	PropertyNumBlobFiles = "rocksdb.num-blob-files"
+	// PropertyBlobStats is the total number, size and garbage of the blob
+	// files.
+	PropertyBlobStats = "rocksdb.blob-stats"
+	// PropertyTotalBlobFileSize is the total size of all blob files.
+	PropertyTotalBlobFileSize = "rocksdb.total-blob-file-size"
+	// PropertyLiveBlobFileSize is the total size of the blob files
+	// belonging to the latest LSM tree.
+	PropertyLiveBlobFileSize = "rocksdb.live-blob-file-size"
+	// PropertyLiveBlobFileGarbageSize is the total garbage in the blob
+	// files belonging to the latest LSM tree.
+	PropertyLiveBlobFileGarbageSize = "rocksdb.live-blob-file-garbage-size"
+	// PropertyBlobCacheCapacity is the capacity of the blob cache.
+	PropertyBlobCacheCapacity = "rocksdb.blob-cache-capacity"
+	// PropertyBlobCacheUsage is the memory size of the entries residing in
+	// the blob cache.
+	PropertyBlobCacheUsage = "rocksdb.blob-cache-usage"
+	// PropertyBlobCachePinnedUsage is the memory size of the entries pinned
+	// in the blob cache.
+	PropertyBlobCachePinnedUsage = "rocksdb.blob-cache-pinned-usage"
+)
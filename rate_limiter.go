@@ -0,0 +1,48 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+
+// RateLimiter limits the rate of flush and compaction writes. A single rate
+// limiter can be shared by the options of several databases to limit their
+// combined I/O.
+type RateLimiter struct {
+	c *C.rocksdb_ratelimiter_t
+}
+
+// NewGenericRateLimiter creates a RateLimiter.
+//
+// bytesPerSec is the total write rate of flushes and compactions.
+// refillPeriodMicros controls how often tokens are refilled; a smaller
+// value spreads the writes more evenly but costs more CPU. 100ms is a
+// good default.
+// fairness controls how often low priority requests (compactions) are
+// served before high priority requests (flushes), 1/fairness of the time.
+// 10 is a good default.
+func NewGenericRateLimiter(bytesPerSec, refillPeriodMicros int64, fairness int32) *RateLimiter {
+	return NewNativeRateLimiter(C.rocksdb_ratelimiter_create(C.int64_t(bytesPerSec), C.int64_t(refillPeriodMicros), C.int32_t(fairness)))
+}
+
+// NewNativeRateLimiter creates a RateLimiter object.
+func NewNativeRateLimiter(c *C.rocksdb_ratelimiter_t) *RateLimiter {
+	return &RateLimiter{c}
+}
+
+// SetBytesPerSecond changes the write rate. It takes effect immediately for
+// all databases using the rate limiter.
+func (r *RateLimiter) SetBytesPerSecond(bytesPerSec int64) {
+	C.gorocksdb_ratelimiter_set_bytes_per_second(r.c, C.int64_t(bytesPerSec))
+}
+
+// GetBytesPerSecond returns the write rate.
+func (r *RateLimiter) GetBytesPerSecond() int64 {
+	return int64(C.gorocksdb_ratelimiter_get_bytes_per_second(r.c))
+}
+
+// Destroy deallocates the RateLimiter object. Options and databases the
+// rate limiter was set on keep using it.
+func (r *RateLimiter) Destroy() {
+	C.rocksdb_ratelimiter_destroy(r.c)
+	r.c = nil
+}
@@ -0,0 +1,35 @@
+package gorocksdb
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestRateLimiter(t *testing.T) {
+	rateLimiter := NewGenericRateLimiter(1<<20, 100*1000, 10)
+	defer rateLimiter.Destroy()
+	ensure.DeepEqual(t, rateLimiter.GetBytesPerSecond(), int64(1<<20))
+
+	// a rate limiter can be shared by several databases
+	db1 := newTestDB(t, "TestRateLimiter1", func(opts *Options) {
+		opts.SetRateLimiter(rateLimiter)
+	})
+	defer db1.Close()
+	db2 := newTestDB(t, "TestRateLimiter2", func(opts *Options) {
+		opts.SetRateLimiter(rateLimiter)
+	})
+	defer db2.Close()
+
+	rateLimiter.SetBytesPerSecond(4 << 20)
+	ensure.DeepEqual(t, rateLimiter.GetBytesPerSecond(), int64(4<<20))
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	for _, db := range []*DB{db1, db2} {
+		ensure.Nil(t, db.Put(wo, []byte("key"), []byte("val")))
+		ensure.Nil(t, db.Flush(fo))
+	}
+}
@@ -0,0 +1,123 @@
+package gorocksdb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LeakedObjectsError is returned when a DB could not be closed because
+// iterators or snapshots created from it were still alive.
+type LeakedObjectsError struct {
+	// Objects describes each object which was not released.
+	Objects []string
+}
+
+func (e *LeakedObjectsError) Error() string {
+	return fmt.Sprintf("gorocksdb: %d unreleased object(s): %s", len(e.Objects), strings.Join(e.Objects, ", "))
+}
+
+// refTracker counts the native objects a DB handed out which must be
+// released before the DB itself can be closed.
+type refTracker struct {
+	mu     sync.Mutex
+	nextID uint64
+	live   map[uint64]string
+	// empty is closed whenever live drops to zero entries.
+	empty  chan struct{}
+	closed bool
+}
+
+func newRefTracker() *refTracker {
+	empty := make(chan struct{})
+	close(empty)
+	return &refTracker{
+		live:  make(map[uint64]string),
+		empty: empty,
+	}
+}
+
+// acquire records a new live object and returns the function which
+// releases it. Calling the returned function more than once is a no-op.
+func (t *refTracker) acquire(kind, name string) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		panic("gorocksdb: " + kind + " created on a closed DB")
+	}
+	t.nextID++
+	id := t.nextID
+	if name == "" {
+		name = fmt.Sprintf("%s #%d", kind, id)
+	} else {
+		name = fmt.Sprintf("%s %q", kind, name)
+	}
+	if len(t.live) == 0 {
+		t.empty = make(chan struct{})
+	}
+	t.live[id] = name
+	return func() { t.release(id) }
+}
+
+func (t *refTracker) release(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.live[id]; !ok {
+		return
+	}
+	delete(t.live, id)
+	if len(t.live) == 0 {
+		close(t.empty)
+	}
+}
+
+// closeWhenEmpty waits until all live objects are released and marks the
+// tracker as closed. A negative timeout waits forever. If the timeout
+// expires a *LeakedObjectsError is returned and the tracker stays open.
+func (t *refTracker) closeWhenEmpty(timeout time.Duration) error {
+	var deadline <-chan time.Time
+	if timeout >= 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	for {
+		t.mu.Lock()
+		if len(t.live) == 0 {
+			t.closed = true
+			t.mu.Unlock()
+			return nil
+		}
+		empty := t.empty
+		t.mu.Unlock()
+
+		select {
+		case <-empty:
+		case <-deadline:
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if len(t.live) == 0 {
+				t.closed = true
+				return nil
+			}
+			return t.leakedLocked()
+		}
+	}
+}
+
+// leakedLocked returns the error describing all objects which are still
+// alive. t.mu must be held.
+func (t *refTracker) leakedLocked() error {
+	ids := make([]uint64, 0, len(t.live))
+	for id := range t.live {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	objs := make([]string, len(ids))
+	for i, id := range ids {
+		objs[i] = t.live[id]
+	}
+	return &LeakedObjectsError{Objects: objs}
+}
@@ -0,0 +1,47 @@
+package gorocksdb
+
+import "sync"
+
+// registry is a thread-safe table of Go objects which are referenced from C
+// by an index. Entries are removed once the native object which references
+// them has been destroyed.
+type registry struct {
+	mu    sync.RWMutex
+	next  int
+	items map[int]interface{}
+}
+
+func newRegistry() *registry {
+	return &registry{items: make(map[int]interface{})}
+}
+
+// register adds v to the registry and returns its index.
+func (r *registry) register(v interface{}) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	idx := r.next
+	r.next++
+	r.items[idx] = v
+	return idx
+}
+
+// lookup returns the object stored at idx.
+func (r *registry) lookup(idx int) interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.items[idx]
+}
+
+// unregister removes the object stored at idx.
+func (r *registry) unregister(idx int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.items, idx)
+}
+
+// len returns the number of registered objects.
+func (r *registry) len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.items)
+}
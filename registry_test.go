@@ -0,0 +1,64 @@
+package gorocksdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestRegistryConcurrentOpen(t *testing.T) {
+	var (
+		numComparators    = comparators.len()
+		numMergeOperators = mergeOperators.len()
+		numSliceTransform = sliceTransforms.len()
+		numFilters        = compactionFilters.len()
+		wg                sync.WaitGroup
+	)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			openAndCloseTestDB(&fatalAsError{t}, fmt.Sprintf("TestRegistryConcurrentOpen-%d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	// all callbacks must have been released with their native objects
+	ensure.DeepEqual(t, comparators.len(), numComparators)
+	ensure.DeepEqual(t, mergeOperators.len(), numMergeOperators)
+	ensure.DeepEqual(t, sliceTransforms.len(), numSliceTransform)
+	ensure.DeepEqual(t, compactionFilters.len(), numFilters)
+}
+
+func openAndCloseTestDB(t ensure.Fataler, name string) {
+	dir, err := ioutil.TempDir("", "gorocksdb-"+name)
+	ensure.Nil(t, err)
+
+	bbto := NewDefaultBlockBasedTableOptions()
+	bbto.SetFilterPolicy(NewBloomFilter(10))
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissing(true)
+	opts.SetComparator(&bytesReverseComparator{})
+	opts.SetMergeOperator(&mockMergeOperator{})
+	opts.SetPrefixExtractor(&testSliceTransform{})
+	opts.SetCompactionFilter(&mockCompactionFilter{
+		filter: func(level int, key, val []byte) (bool, []byte) { return false, nil },
+	})
+	opts.SetBlockBasedTableFactory(bbto)
+
+	db, err := OpenDb(opts, dir)
+	ensure.Nil(t, err)
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val")))
+	ensure.Nil(t, db.Flush(fo))
+
+	db.Close()
+	opts.Destroy()
+	bbto.Destroy()
+}
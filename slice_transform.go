@@ -38,17 +38,20 @@ func (st nativeSliceTransform) InRange(src []byte) bool     { return false }
 func (st nativeSliceTransform) Name() string                { return "" }
 
 // Hold references to slice transforms.
-var sliceTransforms []SliceTransform
+var sliceTransforms = newRegistry()
 
 func registerSliceTransform(st SliceTransform) int {
-	sliceTransforms = append(sliceTransforms, st)
-	return len(sliceTransforms) - 1
+	return sliceTransforms.register(st)
+}
+
+func getSliceTransform(idx int) SliceTransform {
+	return sliceTransforms.lookup(idx).(SliceTransform)
 }
 
 //export gorocksdb_slicetransform_transform
 func gorocksdb_slicetransform_transform(idx int, cKey *C.char, cKeyLen C.size_t, cDstLen *C.size_t) *C.char {
 	key := charToByte(cKey, cKeyLen)
-	dst := sliceTransforms[idx].Transform(key)
+	dst := getSliceTransform(idx).Transform(key)
 	*cDstLen = C.size_t(len(dst))
 	return cByteSlice(dst)
 }
@@ -56,18 +59,23 @@ func gorocksdb_slicetransform_transform(idx int, cKey *C.char, cKeyLen C.size_t,
 //export gorocksdb_slicetransform_in_domain
 func gorocksdb_slicetransform_in_domain(idx int, cKey *C.char, cKeyLen C.size_t) C.uchar {
 	key := charToByte(cKey, cKeyLen)
-	inDomain := sliceTransforms[idx].InDomain(key)
+	inDomain := getSliceTransform(idx).InDomain(key)
 	return boolToChar(inDomain)
 }
 
 //export gorocksdb_slicetransform_in_range
 func gorocksdb_slicetransform_in_range(idx int, cKey *C.char, cKeyLen C.size_t) C.uchar {
 	key := charToByte(cKey, cKeyLen)
-	inRange := sliceTransforms[idx].InRange(key)
+	inRange := getSliceTransform(idx).InRange(key)
 	return boolToChar(inRange)
 }
 
 //export gorocksdb_slicetransform_name
 func gorocksdb_slicetransform_name(idx int) *C.char {
-	return stringToChar(sliceTransforms[idx].Name())
+	return stringToChar(getSliceTransform(idx).Name())
+}
+
+//export gorocksdb_slicetransform_destruct
+func gorocksdb_slicetransform_destruct(idx int) {
+	sliceTransforms.unregister(idx)
 }
@@ -7,15 +7,22 @@ import "C"
 type Snapshot struct {
 	c   *C.rocksdb_snapshot_t
 	cDb *C.rocksdb_t
+
+	// Releases the reference the snapshot holds on its DB.
+	release func()
 }
 
 // NewNativeSnapshot creates a Snapshot object.
 func NewNativeSnapshot(c *C.rocksdb_snapshot_t, cDb *C.rocksdb_t) *Snapshot {
-	return &Snapshot{c, cDb}
+	return &Snapshot{c: c, cDb: cDb}
 }
 
 // Release removes the snapshot from the database's list of snapshots.
 func (s *Snapshot) Release() {
 	C.rocksdb_release_snapshot(s.cDb, s.c)
 	s.c, s.cDb = nil, nil
+	if s.release != nil {
+		s.release()
+		s.release = nil
+	}
 }
@@ -0,0 +1,133 @@
+package gorocksdb
+
+// #include <stdbool.h>
+// #include "rocksdb/c.h"
+import "C"
+import (
+	"errors"
+	"strings"
+)
+
+// SstFileManager tracks the SST files of the databases whose options it is
+// set on. It can limit the disk space they use and rate limit the deletion
+// of obsolete files.
+type SstFileManager struct {
+	c *C.rocksdb_sst_file_manager_t
+}
+
+// NewSstFileManager creates a SstFileManager which uses the given
+// environment to access and delete files. If env is nil the default
+// environment is used.
+func NewSstFileManager(env *Env) *SstFileManager {
+	if env == nil {
+		env = NewDefaultEnv()
+		defer env.Destroy()
+	}
+	return NewNativeSstFileManager(C.rocksdb_sst_file_manager_create(env.c))
+}
+
+// NewNativeSstFileManager creates a SstFileManager object.
+func NewNativeSstFileManager(c *C.rocksdb_sst_file_manager_t) *SstFileManager {
+	return &SstFileManager{c}
+}
+
+// SetMaxAllowedSpaceUsage sets the maximum disk space the SST files may
+// use. Once it is reached, flushes and compactions fail and the database
+// stops accepting writes, which then return an *OutOfSpaceError.
+// Default: 0 (unlimited)
+func (m *SstFileManager) SetMaxAllowedSpaceUsage(maxAllowedSpace uint64) {
+	C.rocksdb_sst_file_manager_set_max_allowed_space_usage(m.c, C.uint64_t(maxAllowedSpace))
+}
+
+// SetCompactionBufferSize sets the disk space which is kept free for
+// writes other than compactions. Compactions are not started if they could
+// use up this buffer.
+// Default: 0
+func (m *SstFileManager) SetCompactionBufferSize(compactionBufferSize uint64) {
+	C.rocksdb_sst_file_manager_set_compaction_buffer_size(m.c, C.uint64_t(compactionBufferSize))
+}
+
+// IsMaxAllowedSpaceReached returns true if the SST files use at least the
+// maximum allowed space.
+func (m *SstFileManager) IsMaxAllowedSpaceReached() bool {
+	return bool(C.rocksdb_sst_file_manager_is_max_allowed_space_reached(m.c))
+}
+
+// IsMaxAllowedSpaceReachedIncludingCompactions returns true if the SST files
+// and the output of running compactions use at least the maximum allowed
+// space.
+func (m *SstFileManager) IsMaxAllowedSpaceReachedIncludingCompactions() bool {
+	return bool(C.rocksdb_sst_file_manager_is_max_allowed_space_reached_including_compactions(m.c))
+}
+
+// GetTotalSize returns the total size of the tracked SST files.
+func (m *SstFileManager) GetTotalSize() uint64 {
+	return uint64(C.rocksdb_sst_file_manager_get_total_size(m.c))
+}
+
+// SetDeleteRateBytesPerSecond sets the rate at which obsolete files are
+// deleted. Files are moved to a trash directory and deleted in the
+// background at this rate. 0 deletes files immediately.
+// Default: 0
+func (m *SstFileManager) SetDeleteRateBytesPerSecond(deleteRate int64) {
+	C.rocksdb_sst_file_manager_set_delete_rate_bytes_per_second(m.c, C.int64_t(deleteRate))
+}
+
+// GetDeleteRateBytesPerSecond returns the rate at which obsolete files are
+// deleted.
+func (m *SstFileManager) GetDeleteRateBytesPerSecond() int64 {
+	return int64(C.rocksdb_sst_file_manager_get_delete_rate_bytes_per_second(m.c))
+}
+
+// SetMaxTrashDBRatio sets the ratio of trash to live data above which
+// files are deleted immediately instead of being rate limited.
+// Default: 0.25
+func (m *SstFileManager) SetMaxTrashDBRatio(ratio float64) {
+	C.rocksdb_sst_file_manager_set_max_trash_db_ratio(m.c, C.double(ratio))
+}
+
+// GetTotalTrashSize returns the total size of the files waiting to be
+// deleted.
+func (m *SstFileManager) GetTotalTrashSize() uint64 {
+	return uint64(C.rocksdb_sst_file_manager_get_total_trash_size(m.c))
+}
+
+// Destroy deallocates the SstFileManager object. Options and databases it
+// was set on keep using it.
+func (m *SstFileManager) Destroy() {
+	C.rocksdb_sst_file_manager_destroy(m.c)
+	m.c = nil
+}
+
+// OutOfSpaceError is returned by writes and flushes which failed because
+// the disk is full or the maximum space allowed by a SstFileManager was
+// reached.
+type OutOfSpaceError struct {
+	Msg string
+}
+
+func (e *OutOfSpaceError) Error() string {
+	return e.Msg
+}
+
+// IsOutOfSpace returns true if err is an *OutOfSpaceError.
+func IsOutOfSpace(err error) bool {
+	_, ok := err.(*OutOfSpaceError)
+	return ok
+}
+
+// The messages RocksDB's Status::ToString returns for the IOError sub codes
+// kNoSpace and kSpaceLimit. The C API only returns the message of a failed
+// status, so it is the only way to tell these errors apart.
+const (
+	noSpaceErrorPrefix    = "IO error: No space left on device"
+	spaceLimitErrorPrefix = "IO error: Space limit reached"
+)
+
+// newWriteError converts the status message of a failed write.
+func newWriteError(msg string) error {
+	if strings.HasPrefix(msg, noSpaceErrorPrefix) || strings.HasPrefix(msg, spaceLimitErrorPrefix) {
+		return &OutOfSpaceError{Msg: msg}
+	}
+	return errors.New(msg)
+}
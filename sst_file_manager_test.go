@@ -0,0 +1,48 @@
+package gorocksdb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestSstFileManager(t *testing.T) {
+	sfm := NewSstFileManager(nil)
+	defer sfm.Destroy()
+	sfm.SetDeleteRateBytesPerSecond(1 << 20)
+	ensure.DeepEqual(t, sfm.GetDeleteRateBytesPerSecond(), int64(1<<20))
+
+	db := newTestDB(t, "TestSstFileManager", func(opts *Options) {
+		opts.SetSstFileManager(sfm)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	fo := NewDefaultFlushOptions()
+	defer fo.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val")))
+	ensure.Nil(t, db.Flush(fo))
+	size := sfm.GetTotalSize()
+	ensure.True(t, size > 0)
+	ensure.False(t, sfm.IsMaxAllowedSpaceReached())
+
+	// exceeding the limit stops the database from accepting writes
+	sfm.SetMaxAllowedSpaceUsage(size + 1)
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("val")))
+	err := db.Flush(fo)
+	ensure.True(t, sfm.IsMaxAllowedSpaceReached())
+	if err == nil {
+		err = db.Put(wo, []byte("key3"), []byte("val"))
+	}
+	ensure.True(t, IsOutOfSpace(err), err)
+}
+
+func TestNewWriteError(t *testing.T) {
+	ensure.True(t, IsOutOfSpace(newWriteError("IO error: No space left on device: While appending to file")))
+	ensure.True(t, IsOutOfSpace(newWriteError("IO error: Space limit reached: Max allowed space was reached")))
+	ensure.False(t, IsOutOfSpace(newWriteError("Corruption: bad block")))
+	ensure.False(t, IsOutOfSpace(newWriteError("Invalid argument: No space left on device")))
+	ensure.False(t, IsOutOfSpace(errors.New("IO error: No space left on device")))
+}
@@ -0,0 +1,801 @@
+package gorocksdb
+
+// #include <stdlib.h>
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+import (
+	"errors"
+	"sync"
+	"unsafe"
+)
+
+// Statistics gives access to the tickers and histograms collected by a
+// database once statistics have been enabled with Options.EnableStatistics.
+// The statistics object is shared by the options and every database opened
+// with them.
+type Statistics struct {
+	c *C.gorocksdb_statistics_t
+}
+
+// HistogramData holds a snapshot of a histogram.
+type HistogramData struct {
+	Median  float64
+	P95     float64
+	P99     float64
+	Average float64
+	StdDev  float64
+	Max     float64
+	Min     float64
+	Count   uint64
+	Sum     uint64
+}
+
+// GetStatistics returns the statistics object of the options or nil if
+// statistics are not enabled. The returned object stays valid after the
+// options have been destroyed and must be destroyed by the caller.
+func (opts *Options) GetStatistics() *Statistics {
+	c := C.gorocksdb_options_get_statistics(opts.c)
+	if c == nil {
+		return nil
+	}
+	return &Statistics{c: c}
+}
+
+// GetTickerCount returns the current value of the ticker or 0 if the
+// ticker is not supported by the linked RocksDB version.
+func (s *Statistics) GetTickerCount(ticker Ticker) uint64 {
+	value, ok := ticker.lookup()
+	if !ok {
+		return 0
+	}
+	return uint64(C.gorocksdb_statistics_get_ticker_count(s.c, value))
+}
+
+// GetHistogramData returns a snapshot of the histogram. The snapshot is
+// empty if the histogram is not supported by the linked RocksDB version.
+func (s *Statistics) GetHistogramData(histogram Histogram) HistogramData {
+	value, ok := histogram.lookup()
+	if !ok {
+		return HistogramData{}
+	}
+	var data C.gorocksdb_histogram_data_t
+	C.gorocksdb_statistics_get_histogram_data(s.c, value, &data)
+	return HistogramData{
+		Median:  float64(data.median),
+		P95:     float64(data.p95),
+		P99:     float64(data.p99),
+		Average: float64(data.average),
+		StdDev:  float64(data.std_dev),
+		Max:     float64(data.max),
+		Min:     float64(data.min),
+		Count:   uint64(data.count),
+		Sum:     uint64(data.sum),
+	}
+}
+
+// Reset resets all tickers and histograms to zero.
+func (s *Statistics) Reset() error {
+	var cErr *C.char
+	C.gorocksdb_statistics_reset(s.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return errors.New(C.GoString(cErr))
+	}
+	return nil
+}
+
+// String returns a human readable dump of all tickers and histograms.
+func (s *Statistics) String() string {
+	cValue := C.gorocksdb_statistics_to_string(s.c)
+	defer C.free(unsafe.Pointer(cValue))
+	return C.GoString(cValue)
+}
+
+// Destroy releases the reference to the statistics object.
+func (s *Statistics) Destroy() {
+	C.gorocksdb_statistics_destroy(s.c)
+	s.c = nil
+}
+
+// Ticker is a counter collected by Statistics. Tickers are identified by
+// the name RocksDB gives them, e.g. "rocksdb.block.cache.miss", and resolved
+// against the linked RocksDB version when they are read, so the numbering of
+// the rocksdb::Tickers enum does not matter.
+type Ticker string
+
+// String returns the name RocksDB uses for the ticker.
+func (t Ticker) String() string {
+	return string(t)
+}
+
+// Supported reports whether the linked RocksDB version knows the ticker.
+// Tickers get added and removed between RocksDB releases.
+func (t Ticker) Supported() bool {
+	_, ok := t.lookup()
+	return ok
+}
+
+// SupportedTickers returns all tickers known to the linked RocksDB version,
+// including those that have no constant in this package.
+func SupportedTickers() []Ticker {
+	tickersOnce.Do(loadTickers)
+	return append([]Ticker(nil), tickers...)
+}
+
+// The tickers of the linked RocksDB version, in the order of the
+// rocksdb::Tickers enum, and their values in it. They are read once.
+var (
+	tickersOnce  sync.Once
+	tickers      []Ticker
+	tickerValues map[Ticker]C.uint32_t
+)
+
+func loadTickers() {
+	tickerValues = make(map[Ticker]C.uint32_t)
+	for i := 0; ; i++ {
+		var value C.uint32_t
+		cName := C.gorocksdb_ticker_name_at(C.size_t(i), &value)
+		if cName == nil {
+			return
+		}
+		t := Ticker(C.GoString(cName))
+		tickers = append(tickers, t)
+		tickerValues[t] = value
+	}
+}
+
+func (t Ticker) lookup() (C.uint32_t, bool) {
+	tickersOnce.Do(loadTickers)
+	value, ok := tickerValues[t]
+	return value, ok
+}
+
+const (
+	// total block cache misses
+	// REQUIRES: BLOCK_CACHE_MISS == BLOCK_CACHE_INDEX_MISS +
+	//                               BLOCK_CACHE_FILTER_MISS +
+	//                               BLOCK_CACHE_DATA_MISS;
+	TickerBlockCacheMiss Ticker = "rocksdb.block.cache.miss"
+	// total block cache hit
+	// REQUIRES: BLOCK_CACHE_HIT == BLOCK_CACHE_INDEX_HIT +
+	//                              BLOCK_CACHE_FILTER_HIT +
+	//                              BLOCK_CACHE_DATA_HIT;
+	TickerBlockCacheHit Ticker = "rocksdb.block.cache.hit"
+	// # of blocks added to block cache.
+	TickerBlockCacheAdd Ticker = "rocksdb.block.cache.add"
+	// # of failures when adding blocks to block cache.
+	TickerBlockCacheAddFailures Ticker = "rocksdb.block.cache.add.failures"
+	// # of times cache miss when accessing index block from block cache.
+	TickerBlockCacheIndexMiss Ticker = "rocksdb.block.cache.index.miss"
+	// # of times cache hit when accessing index block from block cache.
+	TickerBlockCacheIndexHit Ticker = "rocksdb.block.cache.index.hit"
+	// # of index blocks added to block cache.
+	TickerBlockCacheIndexAdd Ticker = "rocksdb.block.cache.index.add"
+	// # of bytes of index blocks inserted into cache
+	TickerBlockCacheIndexBytesInsert Ticker = "rocksdb.block.cache.index.bytes.insert"
+	// # of times cache miss when accessing filter block from block cache.
+	TickerBlockCacheFilterMiss Ticker = "rocksdb.block.cache.filter.miss"
+	// # of times cache hit when accessing filter block from block cache.
+	TickerBlockCacheFilterHit Ticker = "rocksdb.block.cache.filter.hit"
+	// # of filter blocks added to block cache.
+	TickerBlockCacheFilterAdd Ticker = "rocksdb.block.cache.filter.add"
+	// # of bytes of bloom filter blocks inserted into cache
+	TickerBlockCacheFilterBytesInsert Ticker = "rocksdb.block.cache.filter.bytes.insert"
+	// # of times cache miss when accessing data block from block cache.
+	TickerBlockCacheDataMiss Ticker = "rocksdb.block.cache.data.miss"
+	// # of times cache hit when accessing data block from block cache.
+	TickerBlockCacheDataHit Ticker = "rocksdb.block.cache.data.hit"
+	// # of data blocks added to block cache.
+	TickerBlockCacheDataAdd Ticker = "rocksdb.block.cache.data.add"
+	// # of bytes of data blocks inserted into cache
+	TickerBlockCacheDataBytesInsert Ticker = "rocksdb.block.cache.data.bytes.insert"
+	// # of bytes read from cache.
+	TickerBlockCacheBytesRead Ticker = "rocksdb.block.cache.bytes.read"
+	// # of bytes written into cache.
+	TickerBlockCacheBytesWrite Ticker = "rocksdb.block.cache.bytes.write"
+
+	// # of times bloom filter has avoided file reads i.e. negatives.
+	TickerBloomFilterUseful Ticker = "rocksdb.bloom.filter.useful"
+	// # of times bloom FullFilter has not avoided the reads.
+	TickerBloomFilterFullPositive Ticker = "rocksdb.bloom.filter.full.positive"
+	// # of times bloom FullFilter has not avoided the reads and data actually
+	// exist.
+	TickerBloomFilterFullTruePositive Ticker = "rocksdb.bloom.filter.full.true.positive"
+
+	// # persistent cache hit
+	TickerPersistentCacheHit Ticker = "rocksdb.persistent.cache.hit"
+	// # persistent cache miss
+	TickerPersistentCacheMiss Ticker = "rocksdb.persistent.cache.miss"
+
+	// # total simulation block cache hits
+	TickerSimBlockCacheHit Ticker = "rocksdb.sim.block.cache.hit"
+	// # total simulation block cache misses
+	TickerSimBlockCacheMiss Ticker = "rocksdb.sim.block.cache.miss"
+
+	// # of memtable hits.
+	TickerMemtableHit Ticker = "rocksdb.memtable.hit"
+	// # of memtable misses.
+	TickerMemtableMiss Ticker = "rocksdb.memtable.miss"
+
+	// # of Get() queries served by L0
+	TickerGetHitL0 Ticker = "rocksdb.l0.hit"
+	// # of Get() queries served by L1
+	TickerGetHitL1 Ticker = "rocksdb.l1.hit"
+	// # of Get() queries served by L2 and up
+	TickerGetHitL2AndUp Ticker = "rocksdb.l2andup.hit"
+
+	// COMPACTION_KEY_DROP_* count the reasons for key drop during compaction
+	// There are 4 reasons currently.
+	TickerCompactionKeyDropNewerEntry Ticker = "rocksdb.compaction.key.drop.new" // key was written with a newer value.
+	// Also includes keys dropped for range del.
+	TickerCompactionKeyDropObsolete      Ticker = "rocksdb.compaction.key.drop.obsolete"       // The key is obsolete.
+	TickerCompactionKeyDropRangeDel      Ticker = "rocksdb.compaction.key.drop.range_del"      // key was covered by a range tombstone.
+	TickerCompactionKeyDropUser          Ticker = "rocksdb.compaction.key.drop.user"           // user compaction function has dropped the key.
+	TickerCompactionRangeDelDropObsolete Ticker = "rocksdb.compaction.range_del.drop.obsolete" // all keys in range were deleted.
+	// Deletions obsoleted before bottom level due to file gap optimization.
+	TickerCompactionOptimizedDelDropObsolete Ticker = "rocksdb.compaction.optimized.del.drop.obsolete"
+	// If a compaction was canceled in sfm to prevent ENOSPC
+	TickerCompactionCancelled Ticker = "rocksdb.compaction.cancelled"
+
+	// Number of keys written to the database via the Put and Write call's
+	TickerNumberKeysWritten Ticker = "rocksdb.number.keys.written"
+	// Number of Keys read
+	TickerNumberKeysRead Ticker = "rocksdb.number.keys.read"
+	// Number keys updated if inplace update is enabled
+	TickerNumberKeysUpdated Ticker = "rocksdb.number.keys.updated"
+	// The number of uncompressed bytes issued by DB::Put() DB::Delete()
+	// DB::Merge() and DB::Write().
+	TickerBytesWritten Ticker = "rocksdb.bytes.written"
+	// The number of uncompressed bytes read from DB::Get().  It could be
+	// either from memtables cache or table files.
+	// For the number of logical bytes read from DB::MultiGet()
+	// please use NUMBER_MULTIGET_BYTES_READ.
+	TickerBytesRead Ticker = "rocksdb.bytes.read"
+	// The number of calls to seek/next/prev
+	TickerNumberDBSeek Ticker = "rocksdb.number.db.seek"
+	TickerNumberDBNext Ticker = "rocksdb.number.db.next"
+	TickerNumberDBPrev Ticker = "rocksdb.number.db.prev"
+	// The number of calls to seek/next/prev that returned data
+	TickerNumberDBSeekFound Ticker = "rocksdb.number.db.seek.found"
+	TickerNumberDBNextFound Ticker = "rocksdb.number.db.next.found"
+	TickerNumberDBPrevFound Ticker = "rocksdb.number.db.prev.found"
+	// The number of uncompressed bytes read from an iterator.
+	// Includes size of key and value.
+	TickerIterBytesRead Ticker = "rocksdb.db.iter.bytes.read"
+	TickerNoFileOpens   Ticker = "rocksdb.no.file.opens"
+	TickerNoFileErrors  Ticker = "rocksdb.no.file.errors"
+	// Writer has to wait for compaction or flush to finish.
+	TickerStallMicros Ticker = "rocksdb.stall.micros"
+	// The wait time for db mutex.
+	// Disabled by default. To enable it set stats level to kAll
+	TickerDBMutexWaitMicros Ticker = "rocksdb.db.mutex.wait.micros"
+
+	// Number of MultiGet calls keys read and bytes read
+	TickerNumberMultigetCalls     Ticker = "rocksdb.number.multiget.get"
+	TickerNumberMultigetKeysRead  Ticker = "rocksdb.number.multiget.keys.read"
+	TickerNumberMultigetBytesRead Ticker = "rocksdb.number.multiget.bytes.read"
+
+	TickerNumberMergeFailures Ticker = "rocksdb.number.merge.failures"
+
+	// Prefix filter stats when used for point lookups (Get / MultiGet).
+	// (For prefix filter stats on iterators see *_LEVEL_SEEK_*.)
+	// Checked: filter was queried
+	TickerBloomFilterPrefixChecked Ticker = "rocksdb.bloom.filter.prefix.checked"
+	// Useful: filter returned false so prevented accessing data+index blocks
+	TickerBloomFilterPrefixUseful Ticker = "rocksdb.bloom.filter.prefix.useful"
+	// True positive: found a key matching the point query. When another key
+	// with the same prefix matches it is considered a false positive by
+	// these statistics even though the filter returned a true positive.
+	TickerBloomFilterPrefixTruePositive Ticker = "rocksdb.bloom.filter.prefix.true.positive"
+
+	// Number of times we had to reseek inside an iteration to skip
+	// over large number of keys with same userkey.
+	TickerNumberOfReseeksInIteration Ticker = "rocksdb.number.reseeks.iteration"
+
+	// Record the number of calls to GetUpdatesSince. Useful to keep track of
+	// transaction log iterator refreshes
+	TickerGetUpdatesSinceCalls Ticker = "rocksdb.getupdatessince.calls"
+	TickerWALFileSynced        Ticker = "rocksdb.wal.synced" // Number of times WAL sync is done
+	TickerWALFileBytes         Ticker = "rocksdb.wal.bytes"  // Number of bytes written to WAL
+
+	// Writes can be processed by requesting thread or by the thread at the
+	// head of the writers queue.
+	TickerWriteDoneBySelf   Ticker = "rocksdb.write.self"
+	TickerWriteDoneByOther  Ticker = "rocksdb.write.other"         // Equivalent to writes done for others
+	TickerWriteWithWAL      Ticker = "rocksdb.write.wal"           // Number of Write calls that request WAL
+	TickerCompactReadBytes  Ticker = "rocksdb.compact.read.bytes"  // Bytes read during compaction
+	TickerCompactWriteBytes Ticker = "rocksdb.compact.write.bytes" // Bytes written during compaction
+	TickerFlushWriteBytes   Ticker = "rocksdb.flush.write.bytes"   // Bytes written during flush
+
+	// Compaction read and write statistics broken down by CompactionReason
+	TickerCompactReadBytesMarked    Ticker = "rocksdb.compact.read.marked.bytes"
+	TickerCompactReadBytesPeriodic  Ticker = "rocksdb.compact.read.periodic.bytes"
+	TickerCompactReadBytesTTL       Ticker = "rocksdb.compact.read.ttl.bytes"
+	TickerCompactWriteBytesMarked   Ticker = "rocksdb.compact.write.marked.bytes"
+	TickerCompactWriteBytesPeriodic Ticker = "rocksdb.compact.write.periodic.bytes"
+	TickerCompactWriteBytesTTL      Ticker = "rocksdb.compact.write.ttl.bytes"
+
+	// Number of table's properties loaded directly from file without creating
+	// table reader object.
+	TickerNumberDirectLoadTableProperties Ticker = "rocksdb.number.direct.load.table.properties"
+	TickerNumberSuperversionAcquires      Ticker = "rocksdb.number.superversion_acquires"
+	TickerNumberSuperversionReleases      Ticker = "rocksdb.number.superversion_releases"
+	TickerNumberSuperversionCleanups      Ticker = "rocksdb.number.superversion_cleanups"
+
+	// # of compressions/decompressions executed
+	TickerNumberBlockCompressed   Ticker = "rocksdb.number.block.compressed"
+	TickerNumberBlockDecompressed Ticker = "rocksdb.number.block.decompressed"
+
+	// DEPRECATED / unused (see NUMBER_BLOCK_COMPRESSION_*)
+	TickerNumberBlockNotCompressed Ticker = "rocksdb.number.block.not_compressed"
+	TickerMergeOperationTotalTime  Ticker = "rocksdb.merge.operation.time.nanos"
+	TickerFilterOperationTotalTime Ticker = "rocksdb.filter.operation.time.nanos"
+
+	// Row cache.
+	TickerRowCacheHit  Ticker = "rocksdb.row.cache.hit"
+	TickerRowCacheMiss Ticker = "rocksdb.row.cache.miss"
+
+	// Read amplification statistics.
+	// Read amplification can be calculated using this formula
+	// (READ_AMP_TOTAL_READ_BYTES / READ_AMP_ESTIMATE_USEFUL_BYTES)
+	//
+	// REQUIRES: ReadOptions::read_amp_bytes_per_bit to be enabled
+	TickerReadAmpEstimateUsefulBytes Ticker = "rocksdb.read.amp.estimate.useful.bytes" // Estimate of total bytes actually used.
+	TickerReadAmpTotalReadBytes      Ticker = "rocksdb.read.amp.total.read.bytes"      // Total size of loaded data blocks.
+
+	// Number of refill intervals where rate limiter's bytes are fully consumed.
+	TickerNumberRateLimiterDrains Ticker = "rocksdb.number.rate_limiter.drains"
+
+	// Number of internal keys skipped by Iterator
+	TickerNumberIterSkip Ticker = "rocksdb.number.iter.skip"
+
+	// BlobDB specific stats
+	// # of Put/PutTTL/PutUntil to BlobDB. Only applicable to legacy BlobDB.
+	TickerBlobDBNumPut Ticker = "rocksdb.blobdb.num.put"
+	// # of Write to BlobDB. Only applicable to legacy BlobDB.
+	TickerBlobDBNumWrite Ticker = "rocksdb.blobdb.num.write"
+	// # of Get to BlobDB. Only applicable to legacy BlobDB.
+	TickerBlobDBNumGet Ticker = "rocksdb.blobdb.num.get"
+	// # of MultiGet to BlobDB. Only applicable to legacy BlobDB.
+	TickerBlobDBNumMultiget Ticker = "rocksdb.blobdb.num.multiget"
+	// # of Seek/SeekToFirst/SeekToLast/SeekForPrev to BlobDB iterator. Only
+	// applicable to legacy BlobDB.
+	TickerBlobDBNumSeek Ticker = "rocksdb.blobdb.num.seek"
+	// # of Next to BlobDB iterator. Only applicable to legacy BlobDB.
+	TickerBlobDBNumNext Ticker = "rocksdb.blobdb.num.next"
+	// # of Prev to BlobDB iterator. Only applicable to legacy BlobDB.
+	TickerBlobDBNumPrev Ticker = "rocksdb.blobdb.num.prev"
+	// # of keys written to BlobDB. Only applicable to legacy BlobDB.
+	TickerBlobDBNumKeysWritten Ticker = "rocksdb.blobdb.num.keys.written"
+	// # of keys read from BlobDB. Only applicable to legacy BlobDB.
+	TickerBlobDBNumKeysRead Ticker = "rocksdb.blobdb.num.keys.read"
+	// # of bytes (key + value) written to BlobDB. Only applicable to legacy
+	// BlobDB.
+	TickerBlobDBBytesWritten Ticker = "rocksdb.blobdb.bytes.written"
+	// # of bytes (keys + value) read from BlobDB. Only applicable to legacy
+	// BlobDB.
+	TickerBlobDBBytesRead Ticker = "rocksdb.blobdb.bytes.read"
+	// # of keys written by BlobDB as non-TTL inlined value. Only applicable to
+	// legacy BlobDB.
+	TickerBlobDBWriteInlined Ticker = "rocksdb.blobdb.write.inlined"
+	// # of keys written by BlobDB as TTL inlined value. Only applicable to legacy
+	// BlobDB.
+	TickerBlobDBWriteInlinedTTL Ticker = "rocksdb.blobdb.write.inlined.ttl"
+	// # of keys written by BlobDB as non-TTL blob value. Only applicable to
+	// legacy BlobDB.
+	TickerBlobDBWriteBlob Ticker = "rocksdb.blobdb.write.blob"
+	// # of keys written by BlobDB as TTL blob value. Only applicable to legacy
+	// BlobDB.
+	TickerBlobDBWriteBlobTTL Ticker = "rocksdb.blobdb.write.blob.ttl"
+	// # of bytes written to blob file.
+	TickerBlobDBBlobFileBytesWritten Ticker = "rocksdb.blobdb.blob.file.bytes.written"
+	// # of bytes read from blob file.
+	TickerBlobDBBlobFileBytesRead Ticker = "rocksdb.blobdb.blob.file.bytes.read"
+	// # of times a blob files being synced.
+	TickerBlobDBBlobFileSynced Ticker = "rocksdb.blobdb.blob.file.synced"
+	// # of blob index evicted from base DB by BlobDB compaction filter because
+	// of expiration. Only applicable to legacy BlobDB.
+	TickerBlobDBBlobIndexExpiredCount Ticker = "rocksdb.blobdb.blob.index.expired.count"
+	// size of blob index evicted from base DB by BlobDB compaction filter
+	// because of expiration. Only applicable to legacy BlobDB.
+	TickerBlobDBBlobIndexExpiredSize Ticker = "rocksdb.blobdb.blob.index.expired.size"
+	// # of blob index evicted from base DB by BlobDB compaction filter because
+	// of corresponding file deleted. Only applicable to legacy BlobDB.
+	TickerBlobDBBlobIndexEvictedCount Ticker = "rocksdb.blobdb.blob.index.evicted.count"
+	// size of blob index evicted from base DB by BlobDB compaction filter
+	// because of corresponding file deleted. Only applicable to legacy BlobDB.
+	TickerBlobDBBlobIndexEvictedSize Ticker = "rocksdb.blobdb.blob.index.evicted.size"
+	// # of blob files that were obsoleted by garbage collection. Only applicable
+	// to legacy BlobDB.
+	TickerBlobDBGCNumFiles Ticker = "rocksdb.blobdb.gc.num.files"
+	// # of blob files generated by garbage collection. Only applicable to legacy
+	// BlobDB.
+	TickerBlobDBGCNumNewFiles Ticker = "rocksdb.blobdb.gc.num.new.files"
+	// # of BlobDB garbage collection failures. Only applicable to legacy BlobDB.
+	TickerBlobDBGCFailures Ticker = "rocksdb.blobdb.gc.failures"
+	// # of keys relocated to new blob file by garbage collection.
+	TickerBlobDBGCNumKeysRelocated Ticker = "rocksdb.blobdb.gc.num.keys.relocated"
+	// # of bytes relocated to new blob file by garbage collection.
+	TickerBlobDBGCBytesRelocated Ticker = "rocksdb.blobdb.gc.bytes.relocated"
+	// # of blob files evicted because of BlobDB is full. Only applicable to
+	// legacy BlobDB.
+	TickerBlobDBFIFONumFilesEvicted Ticker = "rocksdb.blobdb.fifo.num.files.evicted"
+	// # of keys in the blob files evicted because of BlobDB is full. Only
+	// applicable to legacy BlobDB.
+	TickerBlobDBFIFONumKeysEvicted Ticker = "rocksdb.blobdb.fifo.num.keys.evicted"
+	// # of bytes in the blob files evicted because of BlobDB is full. Only
+	// applicable to legacy BlobDB.
+	TickerBlobDBFIFOBytesEvicted Ticker = "rocksdb.blobdb.fifo.bytes.evicted"
+
+	// These counters indicate a performance issue in WritePrepared transactions.
+	// We should not seem them ticking them much.
+	// # of times prepare_mutex_ is acquired in the fast path.
+	TickerTxnPrepareMutexOverhead Ticker = "rocksdb.txn.overhead.mutex.prepare"
+	// # of times old_commit_map_mutex_ is acquired in the fast path.
+	TickerTxnOldCommitMapMutexOverhead Ticker = "rocksdb.txn.overhead.mutex.old.commit.map"
+	// # of times we checked a batch for duplicate keys.
+	TickerTxnDuplicateKeyOverhead Ticker = "rocksdb.txn.overhead.duplicate.key"
+	// # of times snapshot_mutex_ is acquired in the fast path.
+	TickerTxnSnapshotMutexOverhead Ticker = "rocksdb.txn.overhead.mutex.snapshot"
+	// # of times ::Get returned TryAgain due to expired snapshot seq
+	TickerTxnGetTryAgain Ticker = "rocksdb.txn.get.tryagain"
+
+	// Number of keys actually found in MultiGet calls (vs number requested by
+	// caller)
+	// NUMBER_MULTIGET_KEYS_READ gives the number requested by caller
+	TickerNumberMultigetKeysFound Ticker = "rocksdb.number.multiget.keys.found"
+
+	TickerNoIteratorCreated Ticker = "rocksdb.num.iterator.created" // number of iterators created
+	TickerNoIteratorDeleted Ticker = "rocksdb.num.iterator.deleted" // number of iterators deleted
+
+	TickerBlockCacheCompressionDictMiss        Ticker = "rocksdb.block.cache.compression.dict.miss"
+	TickerBlockCacheCompressionDictHit         Ticker = "rocksdb.block.cache.compression.dict.hit"
+	TickerBlockCacheCompressionDictAdd         Ticker = "rocksdb.block.cache.compression.dict.add"
+	TickerBlockCacheCompressionDictBytesInsert Ticker = "rocksdb.block.cache.compression.dict.bytes.insert"
+
+	// # of blocks redundantly inserted into block cache.
+	// REQUIRES: BLOCK_CACHE_ADD_REDUNDANT <= BLOCK_CACHE_ADD
+	TickerBlockCacheAddRedundant Ticker = "rocksdb.block.cache.add.redundant"
+	// # of index blocks redundantly inserted into block cache.
+	// REQUIRES: BLOCK_CACHE_INDEX_ADD_REDUNDANT <= BLOCK_CACHE_INDEX_ADD
+	TickerBlockCacheIndexAddRedundant Ticker = "rocksdb.block.cache.index.add.redundant"
+	// # of filter blocks redundantly inserted into block cache.
+	// REQUIRES: BLOCK_CACHE_FILTER_ADD_REDUNDANT <= BLOCK_CACHE_FILTER_ADD
+	TickerBlockCacheFilterAddRedundant Ticker = "rocksdb.block.cache.filter.add.redundant"
+	// # of data blocks redundantly inserted into block cache.
+	// REQUIRES: BLOCK_CACHE_DATA_ADD_REDUNDANT <= BLOCK_CACHE_DATA_ADD
+	TickerBlockCacheDataAddRedundant Ticker = "rocksdb.block.cache.data.add.redundant"
+	// # of dict blocks redundantly inserted into block cache.
+	// REQUIRES: BLOCK_CACHE_COMPRESSION_DICT_ADD_REDUNDANT
+	//           <= BLOCK_CACHE_COMPRESSION_DICT_ADD
+	TickerBlockCacheCompressionDictAddRedundant Ticker = "rocksdb.block.cache.compression.dict.add.redundant"
+
+	// # of files marked as trash by sst file manager and will be deleted
+	// later by background thread.
+	TickerFilesMarkedTrash Ticker = "rocksdb.files.marked.trash"
+	// # of trash files deleted by the background thread from the trash queue.
+	TickerFilesDeletedFromTrashQueue Ticker = "rocksdb.files.marked.trash.deleted"
+	// # of files deleted immediately by sst file manager through delete
+	// scheduler.
+	TickerFilesDeletedImmediately Ticker = "rocksdb.files.deleted.immediately"
+
+	// The counters for error handler not that bg_io_error is the subset of
+	// bg_error and bg_retryable_io_error is the subset of bg_io_error.
+	// The misspelled versions are deprecated and only kept for compatibility.
+	// TODO: remove the misspelled tickers in the next major release.
+	TickerErrorHandlerBGErrorCount                      Ticker = "rocksdb.error.handler.bg.error.count"
+	TickerErrorHandlerBGErrorCountMisspelled            Ticker = "rocksdb.error.handler.bg.errro.count"
+	TickerErrorHandlerBGIOErrorCount                    Ticker = "rocksdb.error.handler.bg.io.error.count"
+	TickerErrorHandlerBGIOErrorCountMisspelled          Ticker = "rocksdb.error.handler.bg.io.errro.count"
+	TickerErrorHandlerBGRetryableIOErrorCount           Ticker = "rocksdb.error.handler.bg.retryable.io.error.count"
+	TickerErrorHandlerBGRetryableIOErrorCountMisspelled Ticker = "rocksdb.error.handler.bg.retryable.io.errro.count"
+	TickerErrorHandlerAutoresumeCount                   Ticker = "rocksdb.error.handler.autoresume.count"
+	TickerErrorHandlerAutoresumeRetryTotalCount         Ticker = "rocksdb.error.handler.autoresume.retry.total.count"
+	TickerErrorHandlerAutoresumeSuccessCount            Ticker = "rocksdb.error.handler.autoresume.success.count"
+
+	// Statistics for memtable garbage collection:
+	// Raw bytes of data (payload) present on memtable at flush time.
+	TickerMemtablePayloadBytesAtFlush Ticker = "rocksdb.memtable.payload.bytes.at.flush"
+	// Outdated bytes of data present on memtable at flush time.
+	TickerMemtableGarbageBytesAtFlush Ticker = "rocksdb.memtable.garbage.bytes.at.flush"
+
+	// Secondary cache statistics
+	TickerSecondaryCacheHits Ticker = "rocksdb.secondary.cache.hits"
+
+	// Bytes read by `VerifyChecksum()` and `VerifyFileChecksums()` APIs.
+	TickerVerifyChecksumReadBytes Ticker = "rocksdb.verify_checksum.read.bytes"
+
+	// Bytes read/written while creating backups
+	TickerBackupReadBytes  Ticker = "rocksdb.backup.read.bytes"
+	TickerBackupWriteBytes Ticker = "rocksdb.backup.write.bytes"
+
+	// Remote compaction read/write statistics
+	TickerRemoteCompactReadBytes  Ticker = "rocksdb.remote.compact.read.bytes"
+	TickerRemoteCompactWriteBytes Ticker = "rocksdb.remote.compact.write.bytes"
+
+	// Tiered storage related statistics
+	TickerHotFileReadBytes  Ticker = "rocksdb.hot.file.read.bytes"
+	TickerWarmFileReadBytes Ticker = "rocksdb.warm.file.read.bytes"
+	TickerColdFileReadBytes Ticker = "rocksdb.cold.file.read.bytes"
+	TickerHotFileReadCount  Ticker = "rocksdb.hot.file.read.count"
+	TickerWarmFileReadCount Ticker = "rocksdb.warm.file.read.count"
+	TickerColdFileReadCount Ticker = "rocksdb.cold.file.read.count"
+
+	// Last level and non-last level read statistics
+	TickerLastLevelReadBytes    Ticker = "rocksdb.last.level.read.bytes"
+	TickerLastLevelReadCount    Ticker = "rocksdb.last.level.read.count"
+	TickerNonLastLevelReadBytes Ticker = "rocksdb.non.last.level.read.bytes"
+	TickerNonLastLevelReadCount Ticker = "rocksdb.non.last.level.read.count"
+
+	// Statistics on iterator Seek() (and variants) for each sorted run. I.e. a
+	// single user Seek() can result in many sorted run Seek()s.
+	// The stats are split between last level and non-last level.
+	// Filtered: a filter such as prefix Bloom filter indicate the Seek() would
+	// not find anything relevant so avoided a likely access to data+index
+	// blocks.
+	TickerLastLevelSeekFiltered Ticker = "rocksdb.last.level.seek.filtered"
+	// Filter match: a filter such as prefix Bloom filter was queried but did
+	// not filter out the seek.
+	TickerLastLevelSeekFilterMatch Ticker = "rocksdb.last.level.seek.filter.match"
+	// At least one data block was accessed for a Seek() (or variant) on a
+	// sorted run.
+	TickerLastLevelSeekData Ticker = "rocksdb.last.level.seek.data"
+	// At least one value() was accessed for the seek (suggesting it was useful)
+	// and no filter such as prefix Bloom was queried.
+	TickerLastLevelSeekDataUsefulNoFilter Ticker = "rocksdb.last.level.seek.data.useful.no.filter"
+	// At least one value() was accessed for the seek (suggesting it was useful)
+	// after querying a filter such as prefix Bloom.
+	TickerLastLevelSeekDataUsefulFilterMatch Ticker = "rocksdb.last.level.seek.data.useful.filter.match"
+	// The same set of stats but for non-last level seeks.
+	TickerNonLastLevelSeekFiltered              Ticker = "rocksdb.non.last.level.seek.filtered"
+	TickerNonLastLevelSeekFilterMatch           Ticker = "rocksdb.non.last.level.seek.filter.match"
+	TickerNonLastLevelSeekData                  Ticker = "rocksdb.non.last.level.seek.data"
+	TickerNonLastLevelSeekDataUsefulNoFilter    Ticker = "rocksdb.non.last.level.seek.data.useful.no.filter"
+	TickerNonLastLevelSeekDataUsefulFilterMatch Ticker = "rocksdb.non.last.level.seek.data.useful.filter.match"
+
+	// Number of block checksum verifications
+	TickerBlockChecksumComputeCount Ticker = "rocksdb.block.checksum.compute.count"
+	// Number of times RocksDB detected a corruption while verifying a block
+	// checksum. RocksDB does not remember corruptions that happened during user
+	// reads so the same block corruption may be detected multiple times.
+	TickerBlockChecksumMismatchCount Ticker = "rocksdb.block.checksum.mismatch.count"
+
+	TickerMultigetCoroutineCount Ticker = "rocksdb.multiget.coroutine.count"
+
+	// Integrated BlobDB specific stats
+	// # of times cache miss when accessing blob from blob cache.
+	TickerBlobDBCacheMiss Ticker = "rocksdb.blobdb.cache.miss"
+	// # of times cache hit when accessing blob from blob cache.
+	TickerBlobDBCacheHit Ticker = "rocksdb.blobdb.cache.hit"
+	// # of data blocks added to blob cache.
+	TickerBlobDBCacheAdd Ticker = "rocksdb.blobdb.cache.add"
+	// # of failures when adding blobs to blob cache.
+	TickerBlobDBCacheAddFailures Ticker = "rocksdb.blobdb.cache.add.failures"
+	// # of bytes read from blob cache.
+	TickerBlobDBCacheBytesRead Ticker = "rocksdb.blobdb.cache.bytes.read"
+	// # of bytes written into blob cache.
+	TickerBlobDBCacheBytesWrite Ticker = "rocksdb.blobdb.cache.bytes.write"
+
+	// Time spent in the ReadAsync file system call
+	TickerReadAsyncMicros Ticker = "rocksdb.read.async.micros"
+	// Number of errors returned to the async read callback
+	TickerAsyncReadErrorCount Ticker = "rocksdb.async.read.error.count"
+
+	// Fine grained secondary cache stats
+	TickerSecondaryCacheFilterHits Ticker = "rocksdb.secondary.cache.filter.hits"
+	TickerSecondaryCacheIndexHits  Ticker = "rocksdb.secondary.cache.index.hits"
+	TickerSecondaryCacheDataHits   Ticker = "rocksdb.secondary.cache.data.hits"
+
+	// Number of lookup into the prefetched tail (see
+	// `TABLE_OPEN_PREFETCH_TAIL_READ_BYTES`)
+	// that can't find its data for table open
+	TickerTableOpenPrefetchTailMiss Ticker = "rocksdb.table.open.prefetch.tail.miss"
+	// Number of lookup into the prefetched tail (see
+	// `TABLE_OPEN_PREFETCH_TAIL_READ_BYTES`)
+	// that finds its data for table open
+	TickerTableOpenPrefetchTailHit Ticker = "rocksdb.table.open.prefetch.tail.hit"
+
+	// Statistics on the filtering by user-defined timestamps
+	// # of times timestamps are checked on accessing the table
+	TickerTimestampFilterTableChecked Ticker = "rocksdb.timestamp.filter.table.checked"
+	// # of times timestamps can successfully help skip the table access
+	TickerTimestampFilterTableFiltered Ticker = "rocksdb.timestamp.filter.table.filtered"
+
+	// Number of input bytes (uncompressed) to compression for SST blocks that
+	// are stored compressed.
+	TickerBytesCompressedFrom Ticker = "rocksdb.bytes.compressed.from"
+	// Number of output bytes (compressed) from compression for SST blocks that
+	// are stored compressed.
+	TickerBytesCompressedTo Ticker = "rocksdb.bytes.compressed.to"
+	// Number of uncompressed bytes for SST blocks that are stored uncompressed
+	// because compression type is kNoCompression or some error case caused
+	// compression not to run or produce an output. Index blocks are only counted
+	// if enable_index_compression is true.
+	TickerBytesCompressionBypassed Ticker = "rocksdb.bytes.compression_bypassed"
+	// Number of input bytes (uncompressed) to compression for SST blocks that
+	// are stored uncompressed because the compression result was rejected
+	// either because the ratio was not acceptable (see
+	// CompressionOptions::max_compressed_bytes_per_kb) or found invalid by the
+	// `verify_compression` option.
+	TickerBytesCompressionRejected Ticker = "rocksdb.bytes.compression.rejected"
+
+	// Like BYTES_COMPRESSION_BYPASSED but counting number of blocks
+	TickerNumberBlockCompressionBypassed Ticker = "rocksdb.number.block_compression_bypassed"
+	// Like BYTES_COMPRESSION_REJECTED but counting number of blocks
+	TickerNumberBlockCompressionRejected Ticker = "rocksdb.number.block_compression_rejected"
+
+	// Number of input bytes (compressed) to decompression in reading compressed
+	// SST blocks from storage.
+	TickerBytesDecompressedFrom Ticker = "rocksdb.bytes.decompressed.from"
+	// Number of output bytes (uncompressed) from decompression in reading
+	// compressed SST blocks from storage.
+	TickerBytesDecompressedTo Ticker = "rocksdb.bytes.decompressed.to"
+
+	// Number of times readahead is trimmed during scans when
+	// ReadOptions.auto_readahead_size is set.
+	TickerReadaheadTrimmed Ticker = "rocksdb.readahead.trimmed"
+)
+
+// Histogram is a distribution collected by Statistics. Like tickers,
+// histograms are identified by their RocksDB name, e.g.
+// "rocksdb.db.get.micros".
+type Histogram string
+
+// String returns the name RocksDB uses for the histogram.
+func (h Histogram) String() string {
+	return string(h)
+}
+
+// Supported reports whether the linked RocksDB version knows the histogram.
+func (h Histogram) Supported() bool {
+	_, ok := h.lookup()
+	return ok
+}
+
+// SupportedHistograms returns all histograms known to the linked RocksDB
+// version, including those that have no constant in this package.
+func SupportedHistograms() []Histogram {
+	histogramsOnce.Do(loadHistograms)
+	return append([]Histogram(nil), histograms...)
+}
+
+// The histograms of the linked RocksDB version, in the order of the
+// rocksdb::Histograms enum, and their values in it. They are read once.
+var (
+	histogramsOnce  sync.Once
+	histograms      []Histogram
+	histogramValues map[Histogram]C.uint32_t
+)
+
+func loadHistograms() {
+	histogramValues = make(map[Histogram]C.uint32_t)
+	for i := 0; ; i++ {
+		var value C.uint32_t
+		cName := C.gorocksdb_histogram_name_at(C.size_t(i), &value)
+		if cName == nil {
+			return
+		}
+		h := Histogram(C.GoString(cName))
+		histograms = append(histograms, h)
+		histogramValues[h] = value
+	}
+}
+
+func (h Histogram) lookup() (C.uint32_t, bool) {
+	histogramsOnce.Do(loadHistograms)
+	value, ok := histogramValues[h]
+	return value, ok
+}
+
+const (
+	HistogramDBGet                       Histogram = "rocksdb.db.get.micros"
+	HistogramDBWrite                     Histogram = "rocksdb.db.write.micros"
+	HistogramCompactionTime              Histogram = "rocksdb.compaction.times.micros"
+	HistogramCompactionCPUTime           Histogram = "rocksdb.compaction.times.cpu_micros"
+	HistogramSubcompactionSetupTime      Histogram = "rocksdb.subcompaction.setup.times.micros"
+	HistogramTableSyncMicros             Histogram = "rocksdb.table.sync.micros"
+	HistogramCompactionOutfileSyncMicros Histogram = "rocksdb.compaction.outfile.sync.micros"
+	HistogramWALFileSyncMicros           Histogram = "rocksdb.wal.file.sync.micros"
+	HistogramManifestFileSyncMicros      Histogram = "rocksdb.manifest.file.sync.micros"
+	// TIME SPENT IN IO DURING TABLE OPEN
+	HistogramTableOpenIOMicros          Histogram = "rocksdb.table.open.io.micros"
+	HistogramDBMultiget                 Histogram = "rocksdb.db.multiget.micros"
+	HistogramReadBlockCompactionMicros  Histogram = "rocksdb.read.block.compaction.micros"
+	HistogramReadBlockGetMicros         Histogram = "rocksdb.read.block.get.micros"
+	HistogramWriteRawBlockMicros        Histogram = "rocksdb.write.raw.block.micros"
+	HistogramNumFilesInSingleCompaction Histogram = "rocksdb.numfiles.in.singlecompaction"
+	HistogramDBSeek                     Histogram = "rocksdb.db.seek.micros"
+	HistogramWriteStall                 Histogram = "rocksdb.db.write.stall"
+	// Time spent in reading block-based or plain SST table
+	HistogramSSTReadMicros Histogram = "rocksdb.sst.read.micros"
+	// Time spent in reading SST table (currently only block-based table) or blob
+	// file corresponding to `Env::IOActivity`
+	HistogramFileReadFlushMicros      Histogram = "rocksdb.file.read.flush.micros"
+	HistogramFileReadCompactionMicros Histogram = "rocksdb.file.read.compaction.micros"
+	HistogramFileReadDBOpenMicros     Histogram = "rocksdb.file.read.db.open.micros"
+	// The following `FILE_READ_*` require stats level greater than
+	// `StatsLevel::kExceptDetailedTimers`
+	HistogramFileReadGetMicros                 Histogram = "rocksdb.file.read.get.micros"
+	HistogramFileReadMultigetMicros            Histogram = "rocksdb.file.read.multiget.micros"
+	HistogramFileReadDBIteratorMicros          Histogram = "rocksdb.file.read.db.iterator.micros"
+	HistogramFileReadVerifyDBChecksumMicros    Histogram = "rocksdb.file.read.verify.db.checksum.micros"
+	HistogramFileReadVerifyFileChecksumsMicros Histogram = "rocksdb.file.read.verify.file.checksums.micros"
+
+	// The number of subcompactions actually scheduled during a compaction
+	HistogramNumSubcompactionsScheduled Histogram = "rocksdb.num.subcompactions.scheduled"
+	// Value size distribution in each operation
+	HistogramBytesPerRead     Histogram = "rocksdb.bytes.per.read"
+	HistogramBytesPerWrite    Histogram = "rocksdb.bytes.per.write"
+	HistogramBytesPerMultiget Histogram = "rocksdb.bytes.per.multiget"
+
+	HistogramBytesCompressed         Histogram = "rocksdb.bytes.compressed"   // DEPRECATED / unused (see BYTES_COMPRESSED_{FROMTO})
+	HistogramBytesDecompressed       Histogram = "rocksdb.bytes.decompressed" // DEPRECATED / unused (see BYTES_DECOMPRESSED_{FROMTO})
+	HistogramCompressionTimesNanos   Histogram = "rocksdb.compression.times.nanos"
+	HistogramDecompressionTimesNanos Histogram = "rocksdb.decompression.times.nanos"
+	// Number of merge operands passed to the merge operator in user read
+	// requests.
+	HistogramReadNumMergeOperands Histogram = "rocksdb.read.num.merge_operands"
+
+	// BlobDB specific stats
+	// Size of keys written to BlobDB. Only applicable to legacy BlobDB.
+	HistogramBlobDBKeySize Histogram = "rocksdb.blobdb.key.size"
+	// Size of values written to BlobDB. Only applicable to legacy BlobDB.
+	HistogramBlobDBValueSize Histogram = "rocksdb.blobdb.value.size"
+	// BlobDB Put/PutWithTTL/PutUntil/Write latency. Only applicable to legacy
+	// BlobDB.
+	HistogramBlobDBWriteMicros Histogram = "rocksdb.blobdb.write.micros"
+	// BlobDB Get latency. Only applicable to legacy BlobDB.
+	HistogramBlobDBGetMicros Histogram = "rocksdb.blobdb.get.micros"
+	// BlobDB MultiGet latency. Only applicable to legacy BlobDB.
+	HistogramBlobDBMultigetMicros Histogram = "rocksdb.blobdb.multiget.micros"
+	// BlobDB Seek/SeekToFirst/SeekToLast/SeekForPrev latency. Only applicable to
+	// legacy BlobDB.
+	HistogramBlobDBSeekMicros Histogram = "rocksdb.blobdb.seek.micros"
+	// BlobDB Next latency. Only applicable to legacy BlobDB.
+	HistogramBlobDBNextMicros Histogram = "rocksdb.blobdb.next.micros"
+	// BlobDB Prev latency. Only applicable to legacy BlobDB.
+	HistogramBlobDBPrevMicros Histogram = "rocksdb.blobdb.prev.micros"
+	// Blob file write latency.
+	HistogramBlobDBBlobFileWriteMicros Histogram = "rocksdb.blobdb.blob.file.write.micros"
+	// Blob file read latency.
+	HistogramBlobDBBlobFileReadMicros Histogram = "rocksdb.blobdb.blob.file.read.micros"
+	// Blob file sync latency.
+	HistogramBlobDBBlobFileSyncMicros Histogram = "rocksdb.blobdb.blob.file.sync.micros"
+	// BlobDB compression time.
+	HistogramBlobDBCompressionMicros Histogram = "rocksdb.blobdb.compression.micros"
+	// BlobDB decompression time.
+	HistogramBlobDBDecompressionMicros Histogram = "rocksdb.blobdb.decompression.micros"
+	// Time spent flushing memtable to disk
+	HistogramFlushTime    Histogram = "rocksdb.db.flush.micros"
+	HistogramSSTBatchSize Histogram = "rocksdb.sst.batch.size"
+
+	// MultiGet stats logged per level
+	// Num of index and filter blocks read from file system per level.
+	HistogramNumIndexAndFilterBlocksReadPerLevel Histogram = "rocksdb.num.index.and.filter.blocks.read.per.level"
+	// Num of sst files read from file system per level.
+	HistogramNumSSTReadPerLevel Histogram = "rocksdb.num.sst.read.per.level"
+
+	// Error handler statistics
+	HistogramErrorHandlerAutoresumeRetryCount Histogram = "rocksdb.error.handler.autoresume.retry.count"
+
+	// Stats related to asynchronous read requests.
+	HistogramAsyncReadBytes Histogram = "rocksdb.async.read.bytes"
+	HistogramPollWaitMicros Histogram = "rocksdb.poll.wait.micros"
+
+	// Number of prefetched bytes discarded by RocksDB.
+	HistogramPrefetchedBytesDiscarded Histogram = "rocksdb.prefetched.bytes.discarded"
+
+	// Number of IOs issued in parallel in a MultiGet batch
+	HistogramMultigetIOBatchSize Histogram = "rocksdb.multiget.io.batch.size"
+
+	// Number of levels requiring IO for MultiGet
+	HistogramNumLevelReadPerMultiget Histogram = "rocksdb.num.level.read.per.multiget"
+
+	// Wait time for aborting async read in FilePrefetchBuffer destructor
+	HistogramAsyncPrefetchAbortMicros Histogram = "rocksdb.async.prefetch.abort.micros"
+
+	// Number of bytes read for RocksDB's prefetching contents (as opposed to file
+	// system's prefetch) from the end of SST table during block based table open
+	HistogramTableOpenPrefetchTailReadBytes Histogram = "rocksdb.table.open.prefetch.tail.read.bytes"
+)
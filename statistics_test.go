@@ -0,0 +1,62 @@
+package gorocksdb
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestStatistics(t *testing.T) {
+	var stats *Statistics
+	db := newTestDB(t, "TestStatistics", func(opts *Options) {
+		ensure.True(t, opts.GetStatistics() == nil)
+		opts.EnableStatistics()
+		stats = opts.GetStatistics()
+	})
+	defer db.Close()
+	defer stats.Destroy()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	for i := 0; i < 10; i++ {
+		ensure.Nil(t, db.Put(wo, []byte("key"), []byte("val")))
+		v, err := db.Get(ro, []byte("key"))
+		ensure.Nil(t, err)
+		v.Free()
+	}
+
+	ensure.DeepEqual(t, stats.GetTickerCount(TickerNumberKeysWritten), uint64(10))
+	ensure.DeepEqual(t, stats.GetTickerCount(TickerMemtableHit), uint64(10))
+	hist := stats.GetHistogramData(HistogramDBGet)
+	ensure.DeepEqual(t, hist.Count, uint64(10))
+	ensure.True(t, hist.Max >= hist.Median)
+	ensure.StringContains(t, stats.String(), TickerNumberKeysWritten.String())
+
+	ensure.DeepEqual(t, stats.GetTickerCount(Ticker("rocksdb.no.such.ticker")), uint64(0))
+
+	ensure.Nil(t, stats.Reset())
+	ensure.DeepEqual(t, stats.GetTickerCount(TickerNumberKeysWritten), uint64(0))
+	ensure.DeepEqual(t, stats.GetHistogramData(HistogramDBGet).Count, uint64(0))
+}
+
+func TestStatisticsNames(t *testing.T) {
+	ensure.DeepEqual(t, TickerBlockCacheMiss.String(), "rocksdb.block.cache.miss")
+	ensure.DeepEqual(t, HistogramDBGet.String(), "rocksdb.db.get.micros")
+	ensure.True(t, TickerBlockCacheMiss.Supported())
+	ensure.True(t, HistogramDBGet.Supported())
+	ensure.False(t, Ticker("rocksdb.no.such.ticker").Supported())
+	ensure.False(t, Histogram("rocksdb.no.such.histogram").Supported())
+
+	tickers := SupportedTickers()
+	ensure.True(t, len(tickers) > 0)
+	for _, ticker := range tickers {
+		ensure.True(t, ticker.Supported())
+	}
+	histograms := SupportedHistograms()
+	ensure.True(t, len(histograms) > 0)
+	for _, histogram := range histograms {
+		ensure.True(t, histogram.Supported())
+	}
+}
@@ -1,8 +1,11 @@
 package gorocksdb
 
+// #include <stdlib.h>
 import "C"
 import (
+	"errors"
 	"reflect"
+	"sort"
 	"unsafe"
 )
 
@@ -22,6 +25,11 @@ func boolToChar(b bool) C.uchar {
 	return 0
 }
 
+// charToBool converts a C.uchar value to bool.
+func charToBool(c C.uchar) bool {
+	return c != 0
+}
+
 // charToByte converts a *C.char to a byte slice.
 func charToByte(data *C.char, len C.size_t) []byte {
 	var value []byte
@@ -72,3 +80,47 @@ func sizeSlice(data *C.size_t, len C.int) []C.size_t {
 	sH.Cap, sH.Len, sH.Data = int(len), int(len), uintptr(unsafe.Pointer(data))
 	return value
 }
+
+// mapToCStrings converts a string map into C arrays of keys and values,
+// sorted by key. The strings are allocated in the C heap and have to be
+// released with freeCStrings.
+func mapToCStrings(m map[string]string) (keys, values []*C.char) {
+	names := make([]string, 0, len(m))
+	for k := range m {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	keys = make([]*C.char, len(names))
+	values = make([]*C.char, len(names))
+	for i, k := range names {
+		keys[i] = C.CString(k)
+		values[i] = C.CString(m[k])
+	}
+	return keys, values
+}
+
+// setOptions converts the options to C arrays of keys and values, passes
+// them to set and returns the error set reports. An empty map is not
+// passed to set.
+func setOptions(opts map[string]string, set func(num C.int, keys, values **C.char, cErr **C.char)) error {
+	if len(opts) == 0 {
+		return nil
+	}
+	var cErr *C.char
+	cKeys, cValues := mapToCStrings(opts)
+	defer freeCStrings(cKeys)
+	defer freeCStrings(cValues)
+	set(C.int(len(opts)), &cKeys[0], &cValues[0], &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return errors.New(C.GoString(cErr))
+	}
+	return nil
+}
+
+// freeCStrings frees C strings allocated by C.CString.
+func freeCStrings(strs []*C.char) {
+	for _, s := range strs {
+		C.free(unsafe.Pointer(s))
+	}
+}
@@ -0,0 +1,68 @@
+package gorocksdb
+
+// #include <stdbool.h>
+// #include "rocksdb/c.h"
+import "C"
+
+// WriteBufferManager limits the total memory used by the memtables of all
+// column families and databases whose options it is set on.
+type WriteBufferManager struct {
+	c *C.rocksdb_write_buffer_manager_t
+
+	// Hold references for GC.
+	cache *Cache
+}
+
+// NewWriteBufferManager creates a WriteBufferManager which flushes
+// memtables once their total size reaches bufferSize. If cache is not nil
+// the memtable memory is also charged to the cache, so that the cache and
+// the memtables share one memory budget.
+func NewWriteBufferManager(bufferSize int, cache *Cache) *WriteBufferManager {
+	if cache == nil {
+		return NewNativeWriteBufferManager(C.rocksdb_write_buffer_manager_create(C.size_t(bufferSize), false))
+	}
+	wbm := NewNativeWriteBufferManager(C.rocksdb_write_buffer_manager_create_with_cache(C.size_t(bufferSize), cache.c, false))
+	wbm.cache = cache
+	return wbm
+}
+
+// NewNativeWriteBufferManager creates a WriteBufferManager object.
+func NewNativeWriteBufferManager(c *C.rocksdb_write_buffer_manager_t) *WriteBufferManager {
+	return &WriteBufferManager{c: c}
+}
+
+// MemoryUsage returns the total memory used by the memtables.
+func (wbm *WriteBufferManager) MemoryUsage() int {
+	return int(C.rocksdb_write_buffer_manager_memory_usage(wbm.c))
+}
+
+// MutableMemtableMemoryUsage returns the memory used by the active
+// memtables, which are not yet scheduled for flushing.
+func (wbm *WriteBufferManager) MutableMemtableMemoryUsage() int {
+	return int(C.rocksdb_write_buffer_manager_mutable_memtable_memory_usage(wbm.c))
+}
+
+// BufferSize returns the memory limit of the memtables.
+func (wbm *WriteBufferManager) BufferSize() int {
+	return int(C.rocksdb_write_buffer_manager_buffer_size(wbm.c))
+}
+
+// SetBufferSize changes the memory limit of the memtables.
+func (wbm *WriteBufferManager) SetBufferSize(bufferSize int) {
+	C.rocksdb_write_buffer_manager_set_buffer_size(wbm.c, C.size_t(bufferSize))
+}
+
+// SetAllowStall specifies whether writes are stalled once the memory usage
+// exceeds the buffer size until flushes have freed enough memory.
+// Default: false
+func (wbm *WriteBufferManager) SetAllowStall(value bool) {
+	C.rocksdb_write_buffer_manager_set_allow_stall(wbm.c, C.bool(value))
+}
+
+// Destroy deallocates the WriteBufferManager object. Options and databases
+// it was set on keep using it.
+func (wbm *WriteBufferManager) Destroy() {
+	C.rocksdb_write_buffer_manager_destroy(wbm.c)
+	wbm.c = nil
+	wbm.cache = nil
+}
@@ -0,0 +1,41 @@
+package gorocksdb
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestWriteBufferManager(t *testing.T) {
+	cache := NewLRUCache(64 << 20)
+	defer cache.Destroy()
+	wbm := NewWriteBufferManager(32<<20, cache)
+	defer wbm.Destroy()
+	ensure.DeepEqual(t, wbm.BufferSize(), 32<<20)
+
+	// the write buffer manager accounts for the memtables of all databases
+	db1 := newTestDB(t, "TestWriteBufferManager1", func(opts *Options) {
+		opts.SetWriteBufferManager(wbm)
+	})
+	defer db1.Close()
+	db2 := newTestDB(t, "TestWriteBufferManager2", func(opts *Options) {
+		opts.SetWriteBufferManager(wbm)
+	})
+	defer db2.Close()
+
+	usage := wbm.MemoryUsage()
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	val := make([]byte, 1<<10)
+	for i := 0; i < 100; i++ {
+		ensure.Nil(t, db1.Put(wo, []byte{byte(i)}, val))
+		ensure.Nil(t, db2.Put(wo, []byte{byte(i)}, val))
+	}
+	ensure.True(t, wbm.MemoryUsage() > usage)
+	ensure.True(t, wbm.MutableMemtableMemoryUsage() > 0)
+	// the memtable memory is charged to the cache
+	ensure.True(t, cache.GetUsage() > 0)
+
+	wbm.SetBufferSize(64 << 20)
+	ensure.DeepEqual(t, wbm.BufferSize(), 64<<20)
+}